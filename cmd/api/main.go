@@ -1,39 +1,49 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net/http"
-
-	"github.com/Drinnn/eligible-offers-api/internal/handlers"
-	"github.com/Drinnn/eligible-offers-api/internal/middlewares"
-	"github.com/Drinnn/eligible-offers-api/internal/repositories"
-	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
-	"github.com/go-chi/chi"
-	"github.com/go-chi/chi/v5/middleware"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/app"
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
+	"github.com/Drinnn/eligible-offers-api/internal/server"
 )
 
 func main() {
-	offerRepository := repositories.NewInMemoryOfferRepository()
-	upsertOfferUseCase := use_cases.NewUpsertOfferUseCase(offerRepository)
-	upsertOfferHandler := handlers.NewUpsertOfferHandler(upsertOfferUseCase)
-
-	transactionRepository := repositories.NewInMemoryTransactionRepository()
-	ingestTransactionsUseCase := use_cases.NewIngestTransactionsUseCase(transactionRepository)
-	ingestTransactionsHandler := handlers.NewIngestTransactionsHandler(ingestTransactionsUseCase)
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log))
 
-	getEligibleOffersUseCase := use_cases.NewGetEligibleOffersUseCase(offerRepository, transactionRepository)
-	getEligibleOffersHandler := handlers.NewGetEligibleOffersHandler(getEligibleOffersUseCase)
+	httpServer := &http.Server{Addr: cfg.Server.Addr}
+	srv := server.New(httpServer, time.Duration(cfg.Server.ShutdownDelay), time.Duration(cfg.Server.ShutdownTimeout))
 
-	router := chi.NewRouter()
-	router.Use(middlewares.JSON)
-	router.Use(middleware.Logger)
+	application, err := app.New(app.Dependencies{Config: cfg, State: srv})
+	if err != nil {
+		log.Fatal(err)
+	}
+	httpServer.Handler = application.Handler
+	for _, worker := range application.Workers {
+		srv.AddWorker(worker)
+	}
+	for _, closer := range application.Closers {
+		srv.AddCloser(closer)
+	}
 
-	router.Post("/offers", middlewares.ErrorHandler(upsertOfferHandler.Handle))
-	router.Post("/transactions", middlewares.ErrorHandler(ingestTransactionsHandler.Handle))
-	router.Get("/users/{user_id}/eligible-offers", middlewares.ErrorHandler(getEligibleOffersHandler.Handle))
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
 
-	log.Println("Server starting on :8080")
-	if err := http.ListenAndServe(":8080", router); err != nil {
+	slog.Info("server starting", slog.String("addr", cfg.Server.Addr))
+	if err := srv.Run(ctx); err != nil {
 		log.Fatal(err)
 	}
+	slog.Info("server stopped")
 }
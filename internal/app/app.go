@@ -0,0 +1,323 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+	"github.com/Drinnn/eligible-offers-api/internal/handlers"
+	"github.com/Drinnn/eligible-offers-api/internal/metrics"
+	"github.com/Drinnn/eligible-offers-api/internal/middlewares"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/server"
+	"github.com/Drinnn/eligible-offers-api/internal/sinks"
+	"github.com/Drinnn/eligible-offers-api/internal/tracing"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/Drinnn/eligible-offers-api/internal/workers"
+	"github.com/go-chi/chi"
+)
+
+// App is the API wired from its config: the router serving every enabled route, the workers to
+// run beside it and the storage to close once they stop.
+type App struct {
+	Handler http.Handler
+	Workers []server.Worker
+	Closers []io.Closer
+}
+
+type Dependencies struct {
+	Config *config.Config
+	State  use_cases.ServerStateReader // reported by /readyz
+	// TransactionRepository replaces the in-memory transaction storage when set.
+	TransactionRepository repositories.TransactionRepository
+}
+
+// New builds every repository, use case, handler and route the config enables. main and the
+// integration tests both build the API through it.
+func New(deps Dependencies) (*App, error) {
+	cfg := deps.Config
+	app := &App{}
+
+	var appMetrics *metrics.Metrics
+	var repositoryObservers []repositories.OperationObserver
+	var ingestObserver handlers.IngestObserver
+	if cfg.Features.Metrics {
+		appMetrics = metrics.New()
+		repositoryObservers = append(repositoryObservers, appMetrics)
+		ingestObserver = appMetrics
+	}
+	var appTracing *tracing.Tracing
+	if cfg.Tracing.Exporter != "none" {
+		var err error
+		appTracing, err = tracing.New(context.Background(), cfg.Tracing, os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		repositoryObservers = append(repositoryObservers, appTracing)
+		app.Closers = append(app.Closers, appTracing)
+	}
+	repositoryObserver := repositories.JoinObservers(repositoryObservers...)
+
+	// The memory backend is the only one Validate accepts for now.
+	embeddedMCCRepository, err := repositories.NewEmbeddedMCCRepository()
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	mccRepository := repositories.InstrumentMCCRepository(embeddedMCCRepository, repositoryObserver)
+	listMCCsUseCase := use_cases.NewListMCCsUseCase(mccRepository)
+	listMCCsHandler := handlers.NewListMCCsHandler(listMCCsUseCase)
+
+	offerRepository := repositories.InstrumentOfferRepository(repositories.NewInMemoryOfferRepository(), repositoryObserver)
+	segmentRepository := repositories.InstrumentSegmentRepository(repositories.NewInMemorySegmentRepository(), repositoryObserver)
+	userRepository := repositories.InstrumentUserRepository(repositories.NewInMemoryUserRepository(), repositoryObserver)
+	var transactionStore repositories.TransactionRepository = repositories.NewInMemoryTransactionRepository()
+	if deps.TransactionRepository != nil {
+		transactionStore = deps.TransactionRepository
+	}
+	transactionRepository := repositories.InstrumentTransactionRepository(transactionStore, repositoryObserver)
+	offerMatchRepository := repositories.InstrumentOfferMatchRepository(repositories.NewInMemoryOfferMatchRepository(), repositoryObserver)
+	redemptionRepository := repositories.InstrumentRedemptionRepository(repositories.NewInMemoryRedemptionRepository(), repositoryObserver)
+	budgetRepository := repositories.InstrumentBudgetRepository(repositories.NewInMemoryBudgetRepository(), repositoryObserver)
+	experimentRepository := repositories.InstrumentExperimentRepository(repositories.NewInMemoryExperimentRepository(), repositoryObserver)
+
+	getEligibleOffersDependencies := use_cases.GetEligibleOffersDependencies{
+		OfferRepository:      offerRepository,
+		OfferMatchReader:     offerMatchRepository,
+		RedemptionRepository: redemptionRepository,
+		BudgetRepository:     budgetRepository,
+		SegmentRepository:    segmentRepository,
+		UserRepository:       userRepository,
+		ExperimentRepository: experimentRepository,
+	}
+	if appTracing != nil {
+		getEligibleOffersDependencies.EvaluationObserver = appTracing
+	}
+	getEligibleOffersUseCase := use_cases.NewGetEligibleOffersUseCase(getEligibleOffersDependencies)
+	var getEligibleOffers use_cases.GetEligibleOffersExecutor = getEligibleOffersUseCase
+	if appTracing != nil {
+		getEligibleOffers = appTracing.GetEligibleOffers(getEligibleOffers)
+	}
+	if appMetrics != nil {
+		getEligibleOffers = use_cases.NewInstrumentedGetEligibleOffersUseCase(getEligibleOffers, offerRepository, appMetrics)
+	}
+	var eligibilityCache use_cases.EligibilityCacheInvalidator
+	debugVars := make(map[string]expvar.Var)
+	if cfg.Features.EligibilityCache {
+		cachedGetEligibleOffersUseCase := use_cases.NewCachedGetEligibleOffersUseCase(getEligibleOffers, time.Duration(cfg.Cache.TTL), cfg.Cache.Size)
+		debugVars["eligibility_cache"] = expvar.Func(func() any { return cachedGetEligibleOffersUseCase.Stats() })
+		if appMetrics != nil {
+			appMetrics.RegisterEligibilityCache(func() metrics.CacheStats {
+				stats := cachedGetEligibleOffersUseCase.Stats()
+				return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses, Evictions: stats.Evictions, Size: stats.Size}
+			})
+		}
+		getEligibleOffers = cachedGetEligibleOffersUseCase
+		eligibilityCache = cachedGetEligibleOffersUseCase
+	}
+	getEligibleOffersHandler := handlers.NewGetEligibleOffersHandler(getEligibleOffers)
+
+	fileOutboxRepository, err := repositories.NewFileOutboxRepository(cfg.Storage.OutboxPath)
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	app.Closers = append(app.Closers, fileOutboxRepository)
+	outboxRepository := repositories.InstrumentOutboxRepository(fileOutboxRepository, repositoryObserver)
+	fileSnapshotRepository, err := repositories.NewFileEligibilitySnapshotRepository(cfg.Storage.SnapshotPath)
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	app.Closers = append(app.Closers, fileSnapshotRepository)
+	snapshotRepository := repositories.InstrumentEligibilitySnapshotRepository(fileSnapshotRepository, repositoryObserver)
+	reevaluateEligibilityUseCase := use_cases.NewReevaluateEligibilityUseCase(getEligibleOffersUseCase, transactionRepository, snapshotRepository, outboxRepository)
+
+	fileWebhookSubscriptionRepository, err := repositories.NewFileWebhookSubscriptionRepository(cfg.Storage.WebhookSubscriptionPath)
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	app.Closers = append(app.Closers, fileWebhookSubscriptionRepository)
+	webhookSubscriptionRepository := repositories.InstrumentWebhookSubscriptionRepository(fileWebhookSubscriptionRepository, repositoryObserver)
+	fileWebhookDeliveryRepository, err := repositories.NewFileWebhookDeliveryRepository(cfg.Storage.WebhookPath)
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	app.Closers = append(app.Closers, fileWebhookDeliveryRepository)
+	webhookDeliveryRepository := repositories.InstrumentWebhookDeliveryRepository(fileWebhookDeliveryRepository, repositoryObserver)
+	enqueueWebhookDeliveriesUseCase := use_cases.NewEnqueueWebhookDeliveriesUseCase(offerRepository, webhookSubscriptionRepository, webhookDeliveryRepository)
+
+	upsertWebhookSubscriptionUseCase := use_cases.NewUpsertWebhookSubscriptionUseCase(webhookSubscriptionRepository)
+	upsertWebhookSubscriptionHandler := handlers.NewUpsertWebhookSubscriptionHandler(upsertWebhookSubscriptionUseCase)
+	listWebhookSubscriptionsUseCase := use_cases.NewListWebhookSubscriptionsUseCase(webhookSubscriptionRepository)
+	listWebhookSubscriptionsHandler := handlers.NewListWebhookSubscriptionsHandler(listWebhookSubscriptionsUseCase)
+	deleteWebhookSubscriptionUseCase := use_cases.NewDeleteWebhookSubscriptionUseCase(webhookSubscriptionRepository)
+	deleteWebhookSubscriptionHandler := handlers.NewDeleteWebhookSubscriptionHandler(deleteWebhookSubscriptionUseCase)
+	listDeadLettersUseCase := use_cases.NewListDeadLettersUseCase(webhookDeliveryRepository)
+	listDeadLettersHandler := handlers.NewListDeadLettersHandler(listDeadLettersUseCase)
+	replayDeadLettersUseCase := use_cases.NewReplayDeadLettersUseCase(webhookDeliveryRepository)
+	replayDeadLettersHandler := handlers.NewReplayDeadLettersHandler(replayDeadLettersUseCase)
+
+	eventSinks := make([]sinks.Sink, 0)
+	if cfg.Features.Webhooks {
+		eventSinks = append(eventSinks, sinks.NewWebhookSink(enqueueWebhookDeliveriesUseCase))
+	}
+	if cfg.Events.SinkFile != "" {
+		eventSinks = append(eventSinks, sinks.NewFileSink(cfg.Events.SinkFile))
+	}
+	if cfg.Events.SinkURL != "" {
+		eventSinks = append(eventSinks, sinks.NewHTTPSink(cfg.Events.SinkURL, 5*time.Second))
+	}
+	app.Workers = append(app.Workers, workers.NewOutboxDispatcher(outboxRepository, eventSinks, time.Second, 100))
+	if cfg.Features.Webhooks {
+		app.Workers = append(app.Workers, workers.NewWebhookDispatcher(webhookDeliveryRepository, webhookSubscriptionRepository, &http.Client{Timeout: 10 * time.Second}, 8, 5*time.Second, time.Hour, time.Second, 100))
+	}
+
+	// Offer upserts hold segmentLock for reading so referenced segments cannot be deleted meanwhile,
+	// and hold offerMatchLock while rebuilding an offer's matches so ingests cannot interleave.
+	segmentLock := &sync.RWMutex{}
+	offerMatchLock := &sync.RWMutex{}
+	offerRules := use_cases.NewOfferRules(cfg.OfferRules.MaxLookbackDays, cfg.OfferRules.MaxTxnsPerDay, cfg.OfferRules.Severities)
+	upsertOfferUseCase := use_cases.NewUpsertOfferUseCase(use_cases.UpsertOfferDependencies{
+		OfferRepository:              offerRepository,
+		MCCRepository:                mccRepository,
+		SegmentRepository:            segmentRepository,
+		TransactionRepository:        transactionRepository,
+		OfferMatchRepository:         offerMatchRepository,
+		EligibilityCache:             eligibilityCache,
+		ReevaluateEligibilityUseCase: reevaluateEligibilityUseCase,
+		SegmentLock:                  segmentLock,
+		OfferMatchLock:               offerMatchLock,
+		Rules:                        offerRules,
+	})
+	upsertOfferHandler := handlers.NewUpsertOfferHandler(upsertOfferUseCase)
+
+	ingestTransactionsUseCase := use_cases.NewIngestTransactionsUseCase(transactionRepository, offerRepository, offerMatchRepository, eligibilityCache, reevaluateEligibilityUseCase, offerMatchLock)
+	ingestTransactionsHandler := handlers.NewIngestTransactionsHandler(ingestTransactionsUseCase, ingestObserver)
+
+	upsertSegmentUseCase := use_cases.NewUpsertSegmentUseCase(segmentRepository, eligibilityCache)
+	upsertSegmentHandler := handlers.NewUpsertSegmentHandler(upsertSegmentUseCase)
+	listSegmentsUseCase := use_cases.NewListSegmentsUseCase(segmentRepository)
+	listSegmentsHandler := handlers.NewListSegmentsHandler(listSegmentsUseCase)
+	getSegmentUseCase := use_cases.NewGetSegmentUseCase(segmentRepository)
+	getSegmentHandler := handlers.NewGetSegmentHandler(getSegmentUseCase)
+	deleteSegmentUseCase := use_cases.NewDeleteSegmentUseCase(segmentRepository, offerRepository, segmentLock)
+	deleteSegmentHandler := handlers.NewDeleteSegmentHandler(deleteSegmentUseCase)
+	addSegmentMembersUseCase := use_cases.NewAddSegmentMembersUseCase(segmentRepository, eligibilityCache)
+	addSegmentMembersHandler := handlers.NewAddSegmentMembersHandler(addSegmentMembersUseCase)
+
+	upsertUserProfileUseCase := use_cases.NewUpsertUserProfileUseCase(userRepository, eligibilityCache)
+	upsertUserProfileHandler := handlers.NewUpsertUserProfileHandler(upsertUserProfileUseCase)
+	getUserProfileUseCase := use_cases.NewGetUserProfileUseCase(userRepository)
+	getUserProfileHandler := handlers.NewGetUserProfileHandler(getUserProfileUseCase)
+
+	activateOfferUseCase := use_cases.NewActivateOfferUseCase(offerRepository, redemptionRepository, getEligibleOffersUseCase, eligibilityCache)
+	activateOfferHandler := handlers.NewActivateOfferHandler(activateOfferUseCase)
+	redeemOfferUseCase := use_cases.NewRedeemOfferUseCase(offerRepository, redemptionRepository, budgetRepository, outboxRepository, eligibilityCache)
+	redeemOfferHandler := handlers.NewRedeemOfferHandler(redeemOfferUseCase)
+	getOfferBudgetUseCase := use_cases.NewGetOfferBudgetUseCase(offerRepository, budgetRepository)
+	getOfferBudgetHandler := handlers.NewGetOfferBudgetHandler(getOfferBudgetUseCase)
+
+	upsertExperimentUseCase := use_cases.NewUpsertExperimentUseCase(experimentRepository, offerRepository, eligibilityCache)
+	upsertExperimentHandler := handlers.NewUpsertExperimentHandler(upsertExperimentUseCase)
+	getExperimentUseCase := use_cases.NewGetExperimentUseCase(experimentRepository)
+	getExperimentHandler := handlers.NewGetExperimentHandler(getExperimentUseCase)
+	getExperimentAssignmentsUseCase := use_cases.NewGetExperimentAssignmentsUseCase(experimentRepository)
+	getExperimentAssignmentsHandler := handlers.NewGetExperimentAssignmentsHandler(getExperimentAssignmentsUseCase)
+
+	getConfigHandler := handlers.NewGetConfigHandler(cfg)
+	getDebugVarsHandler := handlers.NewGetDebugVarsHandler(debugVars)
+
+	readinessComponents := map[string]repositories.Pinger{
+		"mccs":                  mccRepository,
+		"offers":                offerRepository,
+		"segments":              segmentRepository,
+		"users":                 userRepository,
+		"transactions":          transactionRepository,
+		"offer_matches":         offerMatchRepository,
+		"redemptions":           redemptionRepository,
+		"budgets":               budgetRepository,
+		"experiments":           experimentRepository,
+		"outbox":                outboxRepository,
+		"eligibility_snapshots": snapshotRepository,
+	}
+	var readinessWebhookDeliveries repositories.WebhookDeliveryRepository
+	if cfg.Features.Webhooks {
+		readinessComponents["webhook_subscriptions"] = webhookSubscriptionRepository
+		readinessComponents["webhook_deliveries"] = webhookDeliveryRepository
+		readinessWebhookDeliveries = webhookDeliveryRepository
+	}
+	checkReadinessUseCase := use_cases.NewCheckReadinessUseCase(deps.State, readinessComponents, outboxRepository, readinessWebhookDeliveries)
+	checkReadinessHandler := handlers.NewCheckReadinessHandler(checkReadinessUseCase)
+	livenessHandler := handlers.NewLivenessHandler()
+
+	routeTimeouts := middlewares.RouteTimeouts{
+		Default: time.Duration(cfg.Server.RequestTimeout),
+		Routes:  cfg.Server.RouteTimeoutDurations(),
+	}
+
+	router := chi.NewRouter()
+	// chi wraps these in the middlewares registered before them, which already run for every request.
+	router.NotFound(middlewares.NotFound)
+	router.MethodNotAllowed(middlewares.MethodNotAllowed(router))
+	router.Use(middlewares.RequestID)
+	if appTracing != nil {
+		router.Use(middlewares.Tracing(router, appTracing.TracerProvider()))
+	}
+	if appMetrics != nil {
+		router.Use(middlewares.Metrics(appMetrics))
+	}
+	router.Use(middlewares.JSON)
+	router.Use(middlewares.RequestLogger(router))
+	router.Use(middlewares.MaxBodySize(cfg.Limits.MaxBodyBytes))
+	router.Use(middlewares.Timeout(router, routeTimeouts))
+
+	router.Get("/healthz", middlewares.ErrorHandler(livenessHandler.Handle))
+	router.Get("/readyz", middlewares.ErrorHandler(checkReadinessHandler.Handle))
+	router.Post("/offers", middlewares.ErrorHandler(upsertOfferHandler.Handle))
+	router.Get("/offers/{offer_id}/budget", middlewares.ErrorHandler(getOfferBudgetHandler.Handle))
+	router.Post("/transactions", middlewares.ErrorHandler(ingestTransactionsHandler.Handle))
+	router.Post("/users", middlewares.ErrorHandler(upsertUserProfileHandler.Handle))
+	router.Get("/users/{user_id}", middlewares.ErrorHandler(getUserProfileHandler.Handle))
+	router.Get("/users/{user_id}/eligible-offers", middlewares.ErrorHandler(getEligibleOffersHandler.Handle))
+	router.Post("/users/{user_id}/offers/{offer_id}/activate", middlewares.ErrorHandler(activateOfferHandler.Handle))
+	router.Post("/users/{user_id}/offers/{offer_id}/redeem", middlewares.ErrorHandler(redeemOfferHandler.Handle))
+	router.Get("/admin/config", middlewares.ErrorHandler(getConfigHandler.Handle))
+	if cfg.Features.DebugVars {
+		router.Get("/debug/vars", middlewares.ErrorHandler(getDebugVarsHandler.Handle))
+	}
+	if appMetrics != nil {
+		router.Get("/metrics", appMetrics.Handler().ServeHTTP)
+	}
+	router.Get("/mccs", middlewares.ErrorHandler(listMCCsHandler.Handle))
+	router.Post("/segments", middlewares.ErrorHandler(upsertSegmentHandler.Handle))
+	router.Get("/segments", middlewares.ErrorHandler(listSegmentsHandler.Handle))
+	router.Get("/segments/{segment_id}", middlewares.ErrorHandler(getSegmentHandler.Handle))
+	router.Delete("/segments/{segment_id}", middlewares.ErrorHandler(deleteSegmentHandler.Handle))
+	router.Post("/segments/{segment_id}/members", middlewares.ErrorHandler(addSegmentMembersHandler.Handle))
+	router.Post("/experiments", middlewares.ErrorHandler(upsertExperimentHandler.Handle))
+	router.Get("/experiments/{experiment_id}", middlewares.ErrorHandler(getExperimentHandler.Handle))
+	router.Get("/experiments/{experiment_id}/assignments", middlewares.ErrorHandler(getExperimentAssignmentsHandler.Handle))
+	if cfg.Features.Webhooks {
+		router.Post("/webhooks", middlewares.ErrorHandler(upsertWebhookSubscriptionHandler.Handle))
+		router.Get("/webhooks", middlewares.ErrorHandler(listWebhookSubscriptionsHandler.Handle))
+		router.Delete("/webhooks/{subscription_id}", middlewares.ErrorHandler(deleteWebhookSubscriptionHandler.Handle))
+		router.Get("/webhooks/dead-letters", middlewares.ErrorHandler(listDeadLettersHandler.Handle))
+		router.Post("/webhooks/dead-letters/replay", middlewares.ErrorHandler(replayDeadLettersHandler.Handle))
+	}
+
+	app.Handler = router
+	return app, nil
+}
+
+// Close closes the app's storage, for callers that do not hand it to a server.Server.
+func (a *App) Close() error {
+	var errs []error
+	for _, closer := range a.Closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
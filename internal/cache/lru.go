@@ -0,0 +1,71 @@
+package cache
+
+import "container/list"
+
+// LRU is a size-bounded map that evicts the least recently used entry when full.
+// It is not safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	capacity int
+	order    *list.List // front is most recently used
+	items    map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	element, exists := c.items[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// Add inserts or replaces the value for key and returns the key of the entry it evicted, if any.
+func (c *LRU[K, V]) Add(key K, value V) (K, bool) {
+	var evicted K
+	if element, exists := c.items[key]; exists {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return evicted, false
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() <= c.capacity {
+		return evicted, false
+	}
+
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	evicted = oldest.Value.(*lruEntry[K, V]).key
+	delete(c.items, evicted)
+	return evicted, true
+}
+
+func (c *LRU[K, V]) Remove(key K) {
+	if element, exists := c.items[key]; exists {
+		c.order.Remove(element)
+		delete(c.items, key)
+	}
+}
+
+func (c *LRU[K, V]) Purge() {
+	c.order.Init()
+	c.items = make(map[K]*list.Element)
+}
+
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
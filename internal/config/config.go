@@ -0,0 +1,309 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// Config holds every setting the server reads at startup.
+type Config struct {
+	Server     ServerConfig     `json:"server"`
+	Storage    StorageConfig    `json:"storage"`
+	Events     EventsConfig     `json:"events"`
+	Limits     LimitsConfig     `json:"limits"`
+	Cache      CacheConfig      `json:"cache"`
+	Log        LogConfig        `json:"log"`
+	Tracing    TracingConfig    `json:"tracing"`
+	OfferRules OfferRulesConfig `json:"offer_rules"`
+	Features   FeaturesConfig   `json:"features"`
+}
+
+type ServerConfig struct {
+	Addr           string              `json:"addr"`
+	RequestTimeout Duration            `json:"request_timeout"`
+	RouteTimeouts  map[string]Duration `json:"route_timeouts"` // keyed by "METHOD /pattern"
+	// ShutdownDelay is how long /readyz fails before the server stops accepting connections.
+	ShutdownDelay Duration `json:"shutdown_delay"`
+	// ShutdownTimeout bounds how long in-flight requests may drain on SIGTERM or SIGINT.
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+}
+
+type StorageConfig struct {
+	Backend                 string `json:"backend"`
+	DSN                     string `json:"dsn"` // secret
+	OutboxPath              string `json:"outbox_path"`
+	SnapshotPath            string `json:"snapshot_path"`
+	WebhookPath             string `json:"webhook_path"`
+	WebhookSubscriptionPath string `json:"webhook_subscription_path"` // holds secrets, created owner-only
+}
+
+type EventsConfig struct {
+	SinkFile string `json:"sink_file"`
+	SinkURL  string `json:"sink_url"` // may embed credentials
+}
+
+type LimitsConfig struct {
+	MaxBodyBytes int64 `json:"max_body_bytes"`
+}
+
+// CacheConfig sizes the eligibility cache. Results are cached per user and TTL-wide bucket of
+// the request's now.
+type CacheConfig struct {
+	TTL  Duration `json:"ttl"`
+	Size int      `json:"size"`
+}
+
+type LogConfig struct {
+	Level  string `json:"level"`
+	Format string `json:"format"` // json or text
+}
+
+type TracingConfig struct {
+	Exporter    string  `json:"exporter"` // none, otlp or stdout
+	Endpoint    string  `json:"endpoint"` // OTLP/HTTP collector URL
+	SampleRatio float64 `json:"sample_ratio"`
+}
+
+// OfferRulesConfig tunes the semantic checks run when an offer is saved. Severities sets each
+// rule to "error" (rejects the offer), "warning" (saves it and reports the problem) or "off".
+type OfferRulesConfig struct {
+	MaxLookbackDays int               `json:"max_lookback_days"`
+	MaxTxnsPerDay   int               `json:"max_txns_per_day"` // used to decide whether min_txn_count is reachable
+	Severities      map[string]string `json:"severities"`
+}
+
+type FeaturesConfig struct {
+	EligibilityCache bool `json:"eligibility_cache"`
+	Webhooks         bool `json:"webhooks"`
+	DebugVars        bool `json:"debug_vars"`
+	Metrics          bool `json:"metrics"`
+}
+
+var (
+	storageBackends = []string{"memory"}
+	logLevels       = []string{"debug", "info", "warn", "error"}
+	logFormats      = []string{"json", "text"}
+	traceExporters  = []string{"none", "otlp", "stdout"}
+	offerRules      = []string{"ends_at_in_past", "max_lookback_days", "reachable_min_txn_count", "duplicate_mccs", "unknown_merchant"}
+	ruleSeverities  = []string{"error", "warning", "off"}
+)
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:           ":8080",
+			RequestTimeout: Duration(5 * time.Second),
+			// Re-evaluating eligibility makes offer and transaction writes slower than reads.
+			RouteTimeouts: map[string]Duration{
+				"POST /offers":       Duration(30 * time.Second),
+				"POST /transactions": Duration(30 * time.Second),
+			},
+			ShutdownTimeout: Duration(30 * time.Second),
+		},
+		Storage: StorageConfig{
+			Backend:                 "memory",
+			OutboxPath:              "outbox.jsonl",
+			SnapshotPath:            "eligibility_snapshots.jsonl",
+			WebhookPath:             "webhook_deliveries.jsonl",
+			WebhookSubscriptionPath: "webhook_subscriptions.jsonl",
+		},
+		Limits: LimitsConfig{
+			MaxBodyBytes: 1 << 20,
+		},
+		Cache: CacheConfig{
+			TTL:  Duration(time.Minute),
+			Size: 10000,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "http://localhost:4318",
+			SampleRatio: 1,
+		},
+		OfferRules: OfferRulesConfig{
+			MaxLookbackDays: 365,
+			MaxTxnsPerDay:   10,
+			Severities: map[string]string{
+				"ends_at_in_past":         "warning",
+				"max_lookback_days":       "error",
+				"reachable_min_txn_count": "error",
+				"duplicate_mccs":          "warning",
+				"unknown_merchant":        "warning",
+			},
+		},
+		Features: FeaturesConfig{
+			EligibilityCache: true,
+			Webhooks:         true,
+			DebugVars:        false,
+			Metrics:          true,
+		},
+	}
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	problems := make([]string, 0)
+
+	if c.Server.Addr == "" {
+		problems = append(problems, "server.addr is required")
+	}
+	if c.Server.RequestTimeout < 0 {
+		problems = append(problems, "server.request_timeout must not be negative")
+	}
+	for route, timeout := range c.Server.RouteTimeouts {
+		if method, pattern, found := strings.Cut(route, " "); !found || method == "" || !strings.HasPrefix(pattern, "/") {
+			problems = append(problems, fmt.Sprintf("server.route_timeouts: %q must be \"METHOD /pattern\"", route))
+		}
+		if timeout < 0 {
+			problems = append(problems, fmt.Sprintf("server.route_timeouts: %q must not be negative", route))
+		}
+	}
+
+	if c.Server.ShutdownDelay < 0 {
+		problems = append(problems, "server.shutdown_delay must not be negative")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdown_timeout must be greater than 0")
+	}
+
+	if !slices.Contains(storageBackends, c.Storage.Backend) {
+		problems = append(problems, fmt.Sprintf("storage.backend must be one of %s", strings.Join(storageBackends, ", ")))
+	}
+	if c.Storage.Backend == "memory" && c.Storage.DSN != "" {
+		problems = append(problems, "storage.dsn is not used by the memory backend")
+	}
+	if c.Storage.OutboxPath == "" {
+		problems = append(problems, "storage.outbox_path is required")
+	}
+	if c.Storage.SnapshotPath == "" {
+		problems = append(problems, "storage.snapshot_path is required")
+	}
+	if c.Storage.WebhookPath == "" {
+		problems = append(problems, "storage.webhook_path is required")
+	}
+	if c.Storage.WebhookSubscriptionPath == "" {
+		problems = append(problems, "storage.webhook_subscription_path is required")
+	}
+
+	if c.Events.SinkURL != "" {
+		if u, err := url.Parse(c.Events.SinkURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, "events.sink_url must be an http(s) URL")
+		}
+	}
+
+	if c.Limits.MaxBodyBytes <= 0 {
+		problems = append(problems, "limits.max_body_bytes must be greater than 0")
+	}
+
+	if c.Cache.TTL <= 0 {
+		problems = append(problems, "cache.ttl must be greater than 0")
+	}
+	if c.Cache.Size <= 0 {
+		problems = append(problems, "cache.size must be greater than 0")
+	}
+
+	if !slices.Contains(logLevels, c.Log.Level) {
+		problems = append(problems, fmt.Sprintf("log.level must be one of %s", strings.Join(logLevels, ", ")))
+	}
+	if !slices.Contains(logFormats, c.Log.Format) {
+		problems = append(problems, fmt.Sprintf("log.format must be one of %s", strings.Join(logFormats, ", ")))
+	}
+
+	if !slices.Contains(traceExporters, c.Tracing.Exporter) {
+		problems = append(problems, fmt.Sprintf("tracing.exporter must be one of %s", strings.Join(traceExporters, ", ")))
+	}
+	if c.Tracing.Exporter == "otlp" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, "tracing.endpoint must be an http(s) URL")
+		}
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "tracing.sample_ratio must be between 0 and 1")
+	}
+
+	if c.OfferRules.MaxLookbackDays <= 0 {
+		problems = append(problems, "offer_rules.max_lookback_days must be greater than 0")
+	}
+	if c.OfferRules.MaxTxnsPerDay <= 0 {
+		problems = append(problems, "offer_rules.max_txns_per_day must be greater than 0")
+	}
+	for rule, severity := range c.OfferRules.Severities {
+		if !slices.Contains(offerRules, rule) {
+			problems = append(problems, fmt.Sprintf("offer_rules.severities: unknown rule %q, must be one of %s", rule, strings.Join(offerRules, ", ")))
+		}
+		if !slices.Contains(ruleSeverities, severity) {
+			problems = append(problems, fmt.Sprintf("offer_rules.severities: %q must be one of %s", rule, strings.Join(ruleSeverities, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Redacted returns a copy that is safe to log or serve, with secrets replaced.
+func (c *Config) Redacted() *Config {
+	copied := *c
+	copied.Server.RouteTimeouts = make(map[string]Duration, len(c.Server.RouteTimeouts))
+	for route, timeout := range c.Server.RouteTimeouts {
+		copied.Server.RouteTimeouts[route] = timeout
+	}
+
+	if copied.Storage.DSN != "" {
+		copied.Storage.DSN = redacted
+	}
+	if u, err := url.Parse(copied.Events.SinkURL); err == nil {
+		copied.Events.SinkURL = u.Redacted()
+	}
+	if u, err := url.Parse(copied.Tracing.Endpoint); err == nil {
+		copied.Tracing.Endpoint = u.Redacted()
+	}
+	return &copied
+}
+
+func (c *LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	level.UnmarshalText([]byte(c.Level))
+	return level
+}
+
+// RouteTimeoutDurations returns the per-route timeouts as plain durations.
+func (c *ServerConfig) RouteTimeoutDurations() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(c.RouteTimeouts))
+	for route, timeout := range c.RouteTimeouts {
+		timeouts[route] = time.Duration(timeout)
+	}
+	return timeouts
+}
+
+// Duration is a time.Duration written as a string ("5s") in config files and responses.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"5s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting is one value that can come from an environment variable and a flag.
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{"LISTEN_ADDR", "addr", "listen address", func(c *Config, v string) error {
+		c.Server.Addr = v
+		return nil
+	}},
+	{"REQUEST_TIMEOUT", "request-timeout", "default request deadline", func(c *Config, v string) error {
+		return setDuration(&c.Server.RequestTimeout, v)
+	}},
+	{"ROUTE_TIMEOUTS", "route-timeouts", `per-route deadlines, e.g. "POST /offers=30s;GET /mccs=1s"`, func(c *Config, v string) error {
+		return setRouteTimeouts(c, v)
+	}},
+	{"SHUTDOWN_DELAY", "shutdown-delay", "how long /readyz fails before shutdown stops accepting connections", func(c *Config, v string) error {
+		return setDuration(&c.Server.ShutdownDelay, v)
+	}},
+	{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long in-flight requests may drain on shutdown", func(c *Config, v string) error {
+		return setDuration(&c.Server.ShutdownTimeout, v)
+	}},
+	{"STORAGE_BACKEND", "storage-backend", "storage backend (memory)", func(c *Config, v string) error {
+		c.Storage.Backend = v
+		return nil
+	}},
+	{"STORAGE_DSN", "storage-dsn", "storage connection string", func(c *Config, v string) error {
+		c.Storage.DSN = v
+		return nil
+	}},
+	{"OUTBOX_PATH", "outbox-path", "event outbox log file", func(c *Config, v string) error {
+		c.Storage.OutboxPath = v
+		return nil
+	}},
+	{"SNAPSHOT_PATH", "snapshot-path", "eligibility snapshot log file", func(c *Config, v string) error {
+		c.Storage.SnapshotPath = v
+		return nil
+	}},
+	{"WEBHOOK_PATH", "webhook-path", "webhook delivery log file", func(c *Config, v string) error {
+		c.Storage.WebhookPath = v
+		return nil
+	}},
+	{"WEBHOOK_SUBSCRIPTION_PATH", "webhook-subscription-path", "webhook subscription log file", func(c *Config, v string) error {
+		c.Storage.WebhookSubscriptionPath = v
+		return nil
+	}},
+	{"EVENT_SINK_FILE", "event-sink-file", "file to append delivered events to", func(c *Config, v string) error {
+		c.Events.SinkFile = v
+		return nil
+	}},
+	{"EVENT_SINK_URL", "event-sink-url", "URL to POST event batches to", func(c *Config, v string) error {
+		c.Events.SinkURL = v
+		return nil
+	}},
+	{"MAX_BODY_BYTES", "max-body-bytes", "maximum request body size in bytes", func(c *Config, v string) error {
+		return setInt(&c.Limits.MaxBodyBytes, v)
+	}},
+	{"CACHE_TTL", "cache-ttl", "how long eligibility results are cached", func(c *Config, v string) error {
+		return setDuration(&c.Cache.TTL, v)
+	}},
+	{"CACHE_SIZE", "cache-size", "maximum cached eligibility results", func(c *Config, v string) error {
+		return setInt(&c.Cache.Size, v)
+	}},
+	{"LOG_LEVEL", "log-level", "log level (debug, info, warn, error)", func(c *Config, v string) error {
+		c.Log.Level = strings.ToLower(v)
+		return nil
+	}},
+	{"LOG_FORMAT", "log-format", "log format (json, text)", func(c *Config, v string) error {
+		c.Log.Format = strings.ToLower(v)
+		return nil
+	}},
+	{"TRACING_EXPORTER", "tracing-exporter", "trace exporter (none, otlp, stdout)", func(c *Config, v string) error {
+		c.Tracing.Exporter = strings.ToLower(v)
+		return nil
+	}},
+	{"TRACING_ENDPOINT", "tracing-endpoint", "OTLP/HTTP collector URL", func(c *Config, v string) error {
+		c.Tracing.Endpoint = v
+		return nil
+	}},
+	{"TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of new traces to sample", func(c *Config, v string) error {
+		return setFloat(&c.Tracing.SampleRatio, v)
+	}},
+	{"OFFER_MAX_LOOKBACK_DAYS", "offer-max-lookback-days", "longest lookback_days an offer may use", func(c *Config, v string) error {
+		return setInt(&c.OfferRules.MaxLookbackDays, v)
+	}},
+	{"OFFER_MAX_TXNS_PER_DAY", "offer-max-txns-per-day", "transactions per day a user can plausibly make", func(c *Config, v string) error {
+		return setInt(&c.OfferRules.MaxTxnsPerDay, v)
+	}},
+	{"OFFER_RULE_SEVERITIES", "offer-rule-severities", `offer rule severities, e.g. "duplicate_mccs=error;unknown_merchant=off"`, func(c *Config, v string) error {
+		return setRuleSeverities(c, v)
+	}},
+	{"FEATURE_ELIGIBILITY_CACHE", "feature-eligibility-cache", "cache eligibility results", func(c *Config, v string) error {
+		return setBool(&c.Features.EligibilityCache, v)
+	}},
+	{"FEATURE_WEBHOOKS", "feature-webhooks", "enable webhook subscriptions and deliveries", func(c *Config, v string) error {
+		return setBool(&c.Features.Webhooks, v)
+	}},
+	{"FEATURE_DEBUG_VARS", "feature-debug-vars", "serve expvar variables at /debug/vars", func(c *Config, v string) error {
+		return setBool(&c.Features.DebugVars, v)
+	}},
+	{"FEATURE_METRICS", "feature-metrics", "serve Prometheus metrics at /metrics", func(c *Config, v string) error {
+		return setBool(&c.Features.Metrics, v)
+	}},
+}
+
+// Load builds the config from defaults, then the JSON file given by -config or CONFIG_FILE,
+// then environment variables, then flags; later sources override earlier ones. The result
+// is validated.
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	flags := flag.NewFlagSet("eligible-offers-api", flag.ContinueOnError)
+	path := flags.String("config", getenv("CONFIG_FILE"), "JSON config file")
+
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		flags.Func(s.flag, s.usage+" (env "+s.env+")", func(value string) error {
+			flagValues[s.flag] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := getenv(s.env); value != "" {
+			if err := s.set(cfg, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+
+	// Apply flags in declaration order so the result does not depend on argument order.
+	for _, s := range settings {
+		if value, exists := flagValues[s.flag]; exists {
+			if err := s.set(cfg, value); err != nil {
+				return nil, fmt.Errorf("-%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func setDuration(target *Duration, value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*target = Duration(parsed)
+	return nil
+}
+
+func setInt[T int | int64](target *T, value string) error {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*target = T(parsed)
+	return nil
+}
+
+func setFloat(target *float64, value string) error {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*target = parsed
+	return nil
+}
+
+func setBool(target *bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*target = parsed
+	return nil
+}
+
+// setRouteTimeouts merges a list like "POST /offers=30s;GET /mccs=1s" into the configured routes.
+func setRouteTimeouts(c *Config, value string) error {
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, timeout, found := strings.Cut(entry, "=")
+		if !found {
+			return fmt.Errorf("route timeout %q: expected \"METHOD /pattern=duration\"", entry)
+		}
+		var duration Duration
+		if err := setDuration(&duration, strings.TrimSpace(timeout)); err != nil {
+			return fmt.Errorf("route timeout %q: %w", entry, err)
+		}
+		if c.Server.RouteTimeouts == nil {
+			c.Server.RouteTimeouts = make(map[string]Duration)
+		}
+		c.Server.RouteTimeouts[strings.Join(strings.Fields(route), " ")] = duration
+	}
+	return nil
+}
+
+// setRuleSeverities merges a list like "duplicate_mccs=error;unknown_merchant=off" into the
+// configured severities.
+func setRuleSeverities(c *Config, value string) error {
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		rule, severity, found := strings.Cut(entry, "=")
+		if !found {
+			return fmt.Errorf("rule severity %q: expected \"rule=severity\"", entry)
+		}
+		if c.OfferRules.Severities == nil {
+			c.OfferRules.Severities = make(map[string]string)
+		}
+		c.OfferRules.Severities[strings.TrimSpace(rule)] = strings.ToLower(strings.TrimSpace(severity))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+type ActivateOfferRequest struct {
+	UserID  string
+	OfferID string
+	Now     time.Time
+}
+
+type ActivateOfferResponse struct {
+	UserID      string    `json:"user_id"`
+	OfferID     string    `json:"offer_id"`
+	Status      string    `json:"status"`
+	ActivatedAt time.Time `json:"activated_at"`
+}
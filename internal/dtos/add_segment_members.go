@@ -0,0 +1,19 @@
+package dtos
+
+import "github.com/Drinnn/eligible-offers-api/internal/helpers"
+
+type AddSegmentMembersRequest struct {
+	SegmentID string   `json:"-"`
+	Replace   bool     `json:"-"`
+	UserIDs   []string `json:"user_ids" validate:"required,dive,required"`
+}
+
+func (r *AddSegmentMembersRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type AddSegmentMembersResponse struct {
+	SegmentID   string `json:"segment_id"`
+	Added       int    `json:"added"`
+	MemberCount int    `json:"member_count"`
+}
@@ -0,0 +1,22 @@
+package dtos
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type CheckReadinessResponse struct {
+	Ready      bool                          `json:"ready"`
+	State      string                        `json:"state"` // starting, serving, draining or stopped
+	Components map[string]ComponentStatusDto `json:"components"`
+	Queues     map[string]QueueStatusDto     `json:"queues"`
+}
+
+type ComponentStatusDto struct {
+	Status string `json:"status"` // up or down
+	Error  string `json:"error,omitempty"`
+}
+
+type QueueStatusDto struct {
+	Depth int    `json:"depth"`
+	Error string `json:"error,omitempty"`
+}
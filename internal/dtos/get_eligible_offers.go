@@ -8,11 +8,31 @@ type GetEligibleOffersRequest struct {
 }
 
 type GetEligibleOffersResponse struct {
-	UserID         string             `json:"user_id"`
-	EligibleOffers []EligibleOfferDto `json:"eligible_offers"`
+	UserID           string             `json:"user_id"`
+	EligibleOffers   []EligibleOfferDto `json:"eligible_offers"`
+	InProgressOffers []EligibleOfferDto `json:"in_progress_offers"`
 }
 
 type EligibleOfferDto struct {
-	OfferID string `json:"offer_id"`
-	Reason  string `json:"reason"`
+	OfferID     string      `json:"offer_id"`
+	Reason      string      `json:"reason"`
+	Status      string      `json:"status"` // in_progress, eligible, activated or redeemed
+	WindowStart time.Time   `json:"window_start"`
+	WindowEnd   time.Time   `json:"window_end"`
+	Progress    ProgressDto `json:"progress"`
+}
+
+type ProgressDto struct {
+	TransactionCount  ProgressValueDto  `json:"transaction_count"`
+	DistinctMerchants *ProgressValueDto `json:"distinct_merchants,omitempty"`
+	Streak            *ProgressValueDto `json:"streak,omitempty"`
+}
+
+type ProgressValueDto struct {
+	Current  int `json:"current"`
+	Required int `json:"required"`
+}
+
+func (p ProgressValueDto) Met() bool {
+	return p.Current >= p.Required
 }
@@ -0,0 +1,19 @@
+package dtos
+
+type GetExperimentAssignmentsRequest struct {
+	ExperimentID string
+}
+
+type GetExperimentAssignmentsResponse struct {
+	ExperimentID string                 `json:"experiment_id"`
+	OfferID      string                 `json:"offer_id"`
+	TotalUsers   int                    `json:"total_users"`
+	Variants     []VariantAssignmentDto `json:"variants"`
+}
+
+type VariantAssignmentDto struct {
+	Variant    string `json:"variant"`
+	Percent    int    `json:"percent"`
+	Users      int    `json:"users"`      // eligible users bucketed into this variant
+	Suppressed int    `json:"suppressed"` // eligible users hidden from results (would-have-been eligible)
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type GetOfferBudgetRequest struct {
+	OfferID string
+	Now     time.Time
+}
+
+type GetOfferBudgetResponse struct {
+	OfferID               string     `json:"offer_id"`
+	BudgetCents           int64      `json:"budget_cents,omitempty"`
+	SpentCents            int64      `json:"spent_cents"`
+	RemainingCents        *int64     `json:"remaining_cents,omitempty"`
+	MaxRedemptions        int        `json:"max_redemptions,omitempty"`
+	Redemptions           int        `json:"redemptions"`
+	RemainingRedemptions  *int       `json:"remaining_redemptions,omitempty"`
+	BurnRateCentsPerDay   float64    `json:"burn_rate_cents_per_day"`
+	RedemptionsPerDay     float64    `json:"redemptions_per_day"`
+	ProjectedExhaustionAt *time.Time `json:"projected_exhaustion_at,omitempty"`
+	Exhausted             bool       `json:"exhausted"`
+}
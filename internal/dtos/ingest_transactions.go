@@ -3,11 +3,12 @@ package dtos
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
 )
 
 type IngestTransactionsRequest struct {
 	Transactions []TransactionDto `json:"transactions" validate:"required,dive"`
+	Now          time.Time        `json:"-"` // evaluation time for eligibility changes
 }
 
 type TransactionDto struct {
@@ -20,8 +21,7 @@ type TransactionDto struct {
 }
 
 func (r *IngestTransactionsRequest) Validate() error {
-	validator := validator.New()
-	return validator.Struct(r)
+	return helpers.ValidateStruct(r)
 }
 
 type IngestTransactionsResponse struct {
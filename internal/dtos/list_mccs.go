@@ -0,0 +1,21 @@
+package dtos
+
+type ListMCCsRequest struct {
+	Category string
+}
+
+type ListMCCsResponse struct {
+	Categories []MCCCategoryDto `json:"categories"`
+	MCCs       []MCCDto         `json:"mccs"`
+}
+
+type MCCCategoryDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type MCCDto struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+type OfferEventDto struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"` // eligibility.gained, eligibility.lost or budget.exhausted
+	UserID     string    `json:"user_id"`
+	OfferID    string    `json:"offer_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type OfferEventBatchDto struct {
+	Events []OfferEventDto `json:"events"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+)
+
+type RedeemOfferRequest struct {
+	UserID      string    `json:"-"`
+	OfferID     string    `json:"-"`
+	Now         time.Time `json:"-"`
+	AmountCents int64     `json:"amount_cents" validate:"gte=0"`
+}
+
+func (r *RedeemOfferRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type RedeemOfferResponse struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	OfferID     string    `json:"offer_id"`
+	Status      string    `json:"status"`
+	AmountCents int64     `json:"amount_cents"`
+	RedeemedAt  time.Time `json:"redeemed_at"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+type ReevaluateEligibilityRequest struct {
+	UserIDs []string // nil re-evaluates every user with transactions
+	Now     time.Time
+}
+
+type ReevaluateEligibilityResponse struct {
+	Evaluated int
+	Events    int
+}
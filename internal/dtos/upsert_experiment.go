@@ -0,0 +1,27 @@
+package dtos
+
+import "github.com/Drinnn/eligible-offers-api/internal/helpers"
+
+type UpsertExperimentRequest struct {
+	ID               string `json:"id"`
+	OfferID          string `json:"offer_id" validate:"required"`
+	Salt             string `json:"salt"`
+	ControlPercent   int    `json:"control_percent" validate:"gte=0,lte=100"`
+	TreatmentPercent int    `json:"treatment_percent" validate:"gte=0,lte=100"`
+}
+
+func (r *UpsertExperimentRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type ExperimentDto struct {
+	ID               string `json:"id"`
+	OfferID          string `json:"offer_id"`
+	Salt             string `json:"salt"`
+	ControlPercent   int    `json:"control_percent"`
+	TreatmentPercent int    `json:"treatment_percent"`
+}
+
+type GetExperimentRequest struct {
+	ExperimentID string
+}
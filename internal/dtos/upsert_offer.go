@@ -3,32 +3,86 @@ package dtos
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
 )
 
 type UpsertOfferRequest struct {
-	ID           string    `json:"id"`
-	MerchantID   string    `json:"merchant_id" validate:"required"`
-	MCCWhitelist []string  `json:"mcc_whitelist" validate:"required,dive,len=4,numeric"`
-	Active       bool      `json:"active"`
-	MinTxnCount  int       `json:"min_txn_count" validate:"required,gt=0"`
-	LookbackDays int       `json:"lookback_days" validate:"required,gt=0"`
-	StartsAt     time.Time `json:"starts_at" validate:"required"`
-	EndsAt       time.Time `json:"ends_at" validate:"required"`
+	ID           string       `json:"id"`
+	MerchantID   string       `json:"merchant_id" validate:"required"`
+	MCCWhitelist []string     `json:"mcc_whitelist" validate:"required,dive,required"` // codes, ranges ("5812-5814") or categories
+	Active       bool         `json:"active"`
+	MinTxnCount  int          `json:"min_txn_count" validate:"required,gt=0"`
+	LookbackDays int          `json:"lookback_days" validate:"omitempty,gt=0"` // required for rolling windows
+	StartsAt     time.Time    `json:"starts_at" validate:"required"`
+	EndsAt       time.Time    `json:"ends_at" validate:"required"`
+	Schedule     *ScheduleDto `json:"schedule"`
+	WindowType   string       `json:"window_type" validate:"omitempty,oneof=rolling calendar_month previous_week since_offer_start"`
+	Timezone     string       `json:"timezone"` // IANA name used for window boundaries
+
+	MinDistinctMerchants int        `json:"min_distinct_merchants" validate:"omitempty,gt=0"`
+	Streak               *StreakDto `json:"streak"`
+
+	SingleUse      bool  `json:"single_use"`
+	MaxRedemptions int   `json:"max_redemptions" validate:"omitempty,gt=0"`
+	BudgetCents    int64 `json:"budget_cents" validate:"omitempty,gt=0"`
+
+	IncludeSegments []string `json:"include_segments" validate:"dive,required"`
+	ExcludeSegments []string `json:"exclude_segments" validate:"dive,required"`
+
+	AttributePredicates []AttributePredicateDto `json:"attribute_predicates" validate:"dive"`
+
+	Now          time.Time `json:"-"` // evaluation time for eligibility changes
+	ValidateOnly bool      `json:"-"` // run every check without saving the offer
+}
+
+type AttributePredicateDto struct {
+	Attribute string   `json:"attribute" validate:"required"` // country, card_tier, signup_date or tags.<key>
+	Operator  string   `json:"operator" validate:"required,oneof=eq neq in not_in exists within_days before after"`
+	Values    []string `json:"values"`
+}
+
+type StreakDto struct {
+	Period string `json:"period" validate:"required,oneof=day week month"`
+	Count  int    `json:"count" validate:"required,gt=0"`
+}
+
+type ScheduleDto struct {
+	Timezone            string   `json:"timezone" validate:"required"`
+	Days                []string `json:"days" validate:"dive,oneof=mon tue wed thu fri sat sun"`
+	StartTime           string   `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime             string   `json:"end_time" validate:"required,datetime=15:04"`
+	ApplyToTransactions bool     `json:"apply_to_transactions"`
 }
 
 func (r *UpsertOfferRequest) Validate() error {
-	validator := validator.New()
-	return validator.Struct(r)
+	return helpers.ValidateStruct(r)
 }
 
 type UpsertOfferResponse struct {
-	ID           string    `json:"id"`
-	MerchantID   string    `json:"merchant_id"`
-	MCCWhitelist []string  `json:"mcc_whitelist"`
-	Active       bool      `json:"active"`
-	MinTxnCount  int       `json:"min_txn_count"`
-	LookbackDays int       `json:"lookback_days"`
-	StartsAt     time.Time `json:"starts_at"`
-	EndsAt       time.Time `json:"ends_at"`
+	ID           string       `json:"id"`
+	MerchantID   string       `json:"merchant_id"`
+	MCCWhitelist []string     `json:"mcc_whitelist"` // as sent
+	MCCCodes     []string     `json:"mcc_codes"`     // expanded against the catalog
+	Active       bool         `json:"active"`
+	MinTxnCount  int          `json:"min_txn_count"`
+	LookbackDays int          `json:"lookback_days"`
+	StartsAt     time.Time    `json:"starts_at"`
+	EndsAt       time.Time    `json:"ends_at"`
+	Schedule     *ScheduleDto `json:"schedule,omitempty"`
+	WindowType   string       `json:"window_type"`
+	Timezone     string       `json:"timezone,omitempty"`
+
+	MinDistinctMerchants int        `json:"min_distinct_merchants,omitempty"`
+	Streak               *StreakDto `json:"streak,omitempty"`
+
+	SingleUse      bool  `json:"single_use"`
+	MaxRedemptions int   `json:"max_redemptions,omitempty"`
+	BudgetCents    int64 `json:"budget_cents,omitempty"`
+
+	IncludeSegments []string `json:"include_segments,omitempty"`
+	ExcludeSegments []string `json:"exclude_segments,omitempty"`
+
+	AttributePredicates []AttributePredicateDto `json:"attribute_predicates,omitempty"`
+
+	Warnings map[string]string `json:"warnings,omitempty"` // problems found by rules that do not reject the offer
 }
@@ -0,0 +1,33 @@
+package dtos
+
+import "github.com/Drinnn/eligible-offers-api/internal/helpers"
+
+type UpsertSegmentRequest struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name" validate:"required"`
+	Description string   `json:"description"`
+	UserIDs     []string `json:"user_ids" validate:"omitempty,dive,required"` // replaces current members when present
+}
+
+func (r *UpsertSegmentRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type SegmentDto struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MemberCount int    `json:"member_count"`
+}
+
+type GetSegmentRequest struct {
+	SegmentID string
+}
+
+type ListSegmentsResponse struct {
+	Segments []SegmentDto `json:"segments"`
+}
+
+type DeleteSegmentRequest struct {
+	SegmentID string
+}
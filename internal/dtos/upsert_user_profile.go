@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+)
+
+type UpsertUserProfileRequest struct {
+	ID         string            `json:"id" validate:"required"`
+	Country    string            `json:"country" validate:"omitempty,iso3166_1_alpha2"`
+	SignupDate time.Time         `json:"signup_date"`
+	CardTier   string            `json:"card_tier"`
+	Tags       map[string]string `json:"tags" validate:"dive,keys,required,endkeys"`
+}
+
+func (r *UpsertUserProfileRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type UserProfileDto struct {
+	ID         string            `json:"id"`
+	Country    string            `json:"country,omitempty"`
+	SignupDate *time.Time        `json:"signup_date,omitempty"`
+	CardTier   string            `json:"card_tier,omitempty"`
+	Tags       map[string]string `json:"tags"`
+}
+
+type GetUserProfileRequest struct {
+	UserID string
+}
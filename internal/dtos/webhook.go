@@ -0,0 +1,72 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+)
+
+type UpsertWebhookSubscriptionRequest struct {
+	ID         string   `json:"id"`
+	MerchantID string   `json:"merchant_id" validate:"required"`
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret"` // generated when empty
+	EventTypes []string `json:"event_types" validate:"dive,oneof=eligibility.gained eligibility.lost budget.exhausted"`
+}
+
+func (r *UpsertWebhookSubscriptionRequest) Validate() error {
+	return helpers.ValidateStruct(r)
+}
+
+type WebhookSubscriptionDto struct {
+	ID         string   `json:"id"`
+	MerchantID string   `json:"merchant_id"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"` // only returned when the subscription is created or updated
+	EventTypes []string `json:"event_types"`
+}
+
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionDto `json:"subscriptions"`
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	SubscriptionID string
+}
+
+// WebhookPayloadDto is the signed body POSTed to subscribers.
+type WebhookPayloadDto struct {
+	ID         string    `json:"id"` // event ID, stable across retries
+	Type       string    `json:"type"`
+	MerchantID string    `json:"merchant_id"`
+	OfferID    string    `json:"offer_id"`
+	UserID     string    `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type WebhookDeliveryDto struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+type ListDeadLettersResponse struct {
+	Deliveries []WebhookDeliveryDto `json:"deliveries"`
+}
+
+type ReplayDeadLettersRequest struct {
+	DeliveryIDs []string  `json:"delivery_ids"` // empty replays every dead-lettered delivery
+	Now         time.Time `json:"-"`
+}
+
+type ReplayDeadLettersResponse struct {
+	Replayed int `json:"replayed"`
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+const tagAttributePrefix = "tags."
+
+// AttributePredicate is a condition on a user profile attribute, e.g. card_tier in [gold, platinum].
+// Attributes are country, card_tier, signup_date or tags.<key>.
+type AttributePredicate struct {
+	Attribute string
+	Operator  string // eq, neq, in, not_in, exists, within_days, before, after
+	Values    []string
+
+	days int
+	at   time.Time
+}
+
+func NewAttributePredicate(attribute, operator string, values []string) (*AttributePredicate, error) {
+	predicate := &AttributePredicate{Attribute: attribute, Operator: operator, Values: values}
+
+	isDate := attribute == "signup_date"
+	isString := attribute == "country" || attribute == "card_tier" || (strings.HasPrefix(attribute, tagAttributePrefix) && len(attribute) > len(tagAttributePrefix))
+	if !isDate && !isString {
+		return nil, customErrors.NewFieldMessage("unknown_attribute", "unknown attribute {0}", strconv.Quote(attribute))
+	}
+
+	switch operator {
+	case "eq", "neq":
+		if !isString || len(values) != 1 {
+			return nil, customErrors.NewFieldMessage("operator_requires_one_text_value", "operator {0} requires a text attribute and exactly one value", operator)
+		}
+	case "in", "not_in":
+		if !isString || len(values) == 0 {
+			return nil, customErrors.NewFieldMessage("operator_requires_text_values", "operator {0} requires a text attribute and at least one value", operator)
+		}
+	case "exists":
+		if !isString || len(values) != 0 {
+			return nil, customErrors.NewFieldMessage("operator_requires_no_values", "operator {0} requires a text attribute and no values", operator)
+		}
+	case "within_days":
+		if !isDate || len(values) != 1 {
+			return nil, customErrors.NewFieldMessage("operator_requires_one_date_value", "operator {0} requires signup_date and exactly one value", operator)
+		}
+		days, err := strconv.Atoi(values[0])
+		if err != nil || days <= 0 {
+			return nil, customErrors.NewFieldMessage("operator_requires_positive_days", "operator {0} requires a positive number of days", operator)
+		}
+		predicate.days = days
+	case "before", "after":
+		if !isDate || len(values) != 1 {
+			return nil, customErrors.NewFieldMessage("operator_requires_one_date_value", "operator {0} requires signup_date and exactly one value", operator)
+		}
+		at, err := time.Parse(time.RFC3339, values[0])
+		if err != nil {
+			return nil, customErrors.NewFieldMessage("operator_requires_timestamp", "operator {0} requires an RFC3339 timestamp", operator)
+		}
+		predicate.at = at
+	default:
+		return nil, customErrors.NewFieldMessage("unknown_operator", "unknown operator {0}", strconv.Quote(operator))
+	}
+
+	return predicate, nil
+}
+
+func (p *AttributePredicate) Matches(user *User, now time.Time) bool {
+	if p.Attribute == "signup_date" {
+		if user.SignupDate.IsZero() {
+			return false
+		}
+		switch p.Operator {
+		case "within_days":
+			return !user.SignupDate.Before(now.AddDate(0, 0, -p.days)) && !user.SignupDate.After(now)
+		case "before":
+			return user.SignupDate.Before(p.at)
+		default:
+			return user.SignupDate.After(p.at)
+		}
+	}
+
+	value, present := p.stringAttribute(user)
+	switch p.Operator {
+	case "eq":
+		return present && value == p.Values[0]
+	case "neq":
+		return !present || value != p.Values[0]
+	case "in":
+		return present && slices.Contains(p.Values, value)
+	case "not_in":
+		return !present || !slices.Contains(p.Values, value)
+	default:
+		return present
+	}
+}
+
+func (p *AttributePredicate) stringAttribute(user *User) (string, bool) {
+	switch p.Attribute {
+	case "country":
+		return user.Country, user.Country != ""
+	case "card_tier":
+		return user.CardTier, user.CardTier != ""
+	default:
+		value, present := user.Tags[strings.TrimPrefix(p.Attribute, tagAttributePrefix)]
+		return value, present
+	}
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// BudgetUsage tracks how much of an offer's redemption cap and budget has been consumed.
+type BudgetUsage struct {
+	OfferID           string // uuid
+	Redemptions       int
+	SpentCents        int64 // integer cents
+	FirstRedemptionAt time.Time
+	LastRedemptionAt  time.Time
+}
+
+// IsExhausted reports whether no further redemption fits within the offer's caps.
+func (o *Offer) IsExhausted(usage *BudgetUsage) bool {
+	if o.MaxRedemptions > 0 && usage.Redemptions >= o.MaxRedemptions {
+		return true
+	}
+	return o.BudgetCents > 0 && usage.SpentCents >= o.BudgetCents
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Variant string
+
+const (
+	VariantControl   Variant = "control"
+	VariantTreatment Variant = "treatment"
+	VariantNone      Variant = "none" // not enrolled, sees the offer normally
+)
+
+type Experiment struct {
+	ID               string // uuid
+	OfferID          string // uuid
+	Salt             string // mixed into the user ID hash, defaults to the experiment ID
+	ControlPercent   int    // 0-100
+	TreatmentPercent int    // 0-100, control + treatment <= 100
+}
+
+type ExperimentExposure struct {
+	ExperimentID string // uuid
+	UserID       string // uuid
+	Variant      Variant
+	Suppressed   bool      // eligible but hidden from the user (control)
+	ExposedAt    time.Time // RFC3339 timestamp
+}
+
+func NewExperiment(id, offerID, salt string, controlPercent, treatmentPercent int) *Experiment {
+	if id == "" {
+		id = uuid.New().String()
+	}
+	if salt == "" {
+		salt = id
+	}
+
+	return &Experiment{
+		ID:               id,
+		OfferID:          offerID,
+		Salt:             salt,
+		ControlPercent:   controlPercent,
+		TreatmentPercent: treatmentPercent,
+	}
+}
+
+// Assign deterministically buckets the user by hashing the salt and user ID into [0, 100).
+func (e *Experiment) Assign(userID string) Variant {
+	sum := sha256.Sum256([]byte(e.Salt + ":" + userID))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % 100)
+
+	switch {
+	case bucket < e.ControlPercent:
+		return VariantControl
+	case bucket < e.ControlPercent+e.TreatmentPercent:
+		return VariantTreatment
+	default:
+		return VariantNone
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "strings"
+
+type MCC struct {
+	Code        string // 4-digit merchant category code
+	Description string
+	Category    string // e.g. "restaurants"
+}
+
+type MCCCategory struct {
+	Name        string // e.g. "restaurants"
+	Description string
+}
+
+func NewMCC(code, description, category string) *MCC {
+	return &MCC{
+		Code:        code,
+		Description: description,
+		Category:    category,
+	}
+}
+
+// NormalizeMCCCategory maps a category name as written by clients, e.g. " Restaurants", to
+// its catalog form.
+func NormalizeMCCCategory(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
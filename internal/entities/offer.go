@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,12 +10,28 @@ import (
 type Offer struct {
 	ID           string   // uuid
 	MerchantID   string   // uuid
-	MCCWhitelist []string // e.g. ["5812", "5814"]
+	MCCWhitelist []string // expanded codes, e.g. ["5812", "5814"]
+	MCCSelectors []string // mcc_whitelist as sent, e.g. ["5812-5814", "restaurants"]
 	Active       bool
-	MinTxnCount  int       // N
-	LookbackDays int       // K days
-	StartsAt     time.Time // RFC3339 timestamp
-	EndsAt       time.Time // RFC3339 timestamp
+	MinTxnCount  int            // N
+	LookbackDays int            // K days
+	StartsAt     time.Time      // RFC3339 timestamp
+	EndsAt       time.Time      // RFC3339 timestamp
+	Schedule     *Schedule      // optional recurring window, nil means always live
+	WindowType   WindowType     // empty means rolling
+	Location     *time.Location // timezone for window boundaries, nil means the zone of now
+
+	MinDistinctMerchants int     // 0 means no requirement
+	Streak               *Streak // optional consecutive-period requirement
+
+	SingleUse      bool  // hide the offer from a user once they redeemed it
+	MaxRedemptions int   // global redemption cap, 0 means unlimited
+	BudgetCents    int64 // total reward budget in integer cents, 0 means unlimited
+
+	IncludeSegments []string // segment IDs, user must belong to at least one (if any)
+	ExcludeSegments []string // segment IDs, user must belong to none
+
+	AttributePredicates []*AttributePredicate // all must match the user's profile
 }
 
 func NewOffer(id, merchantID string, mccWhitelist []string, active bool, minTxnCount, lookbackDays int, startsAt, endsAt time.Time) *Offer {
@@ -33,3 +50,54 @@ func NewOffer(id, merchantID string, mccWhitelist []string, active bool, minTxnC
 		EndsAt:       endsAt,
 	}
 }
+
+// Matches reports whether the transaction counts toward the offer, regardless of when it happened.
+func (o *Offer) Matches(transaction *Transaction) bool {
+	if o.Schedule != nil && o.Schedule.ApplyToTransactions && !o.Schedule.Contains(transaction.ApprovedAt) {
+		return false
+	}
+	return transaction.MerchantID == o.MerchantID || slices.Contains(o.MCCWhitelist, transaction.MCC)
+}
+
+// IsLiveAt reports whether the offer is active, within its date range and inside its schedule at now.
+func (o *Offer) IsLiveAt(now time.Time) bool {
+	return o.Active &&
+		!now.Before(o.StartsAt) &&
+		!now.After(o.EndsAt) &&
+		(o.Schedule == nil || o.Schedule.Contains(now))
+}
+
+// TargetsUser applies the offer's segment rules to the IDs of the segments the user belongs to.
+func (o *Offer) TargetsUser(userSegments map[string]bool) bool {
+	for _, segmentID := range o.ExcludeSegments {
+		if userSegments[segmentID] {
+			return false
+		}
+	}
+	if len(o.IncludeSegments) == 0 {
+		return true
+	}
+	for _, segmentID := range o.IncludeSegments {
+		if userSegments[segmentID] {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesProfile evaluates the offer's attribute predicates. Users without a
+// profile only match offers that have no predicates.
+func (o *Offer) MatchesProfile(user *User, now time.Time) bool {
+	if len(o.AttributePredicates) == 0 {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	for _, predicate := range o.AttributePredicates {
+		if !predicate.Matches(user, now) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfferEventType string
+
+const (
+	EligibilityGained OfferEventType = "eligibility.gained"
+	EligibilityLost   OfferEventType = "eligibility.lost"
+	BudgetExhausted   OfferEventType = "budget.exhausted" // UserID is the user whose redemption exhausted it
+)
+
+type OfferEvent struct {
+	ID         string // uuid
+	Type       OfferEventType
+	UserID     string    // uuid
+	OfferID    string    // uuid
+	OccurredAt time.Time // RFC3339 timestamp
+}
+
+func NewOfferEvent(eventType OfferEventType, userID, offerID string, occurredAt time.Time) *OfferEvent {
+	return &OfferEvent{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		UserID:     userID,
+		OfferID:    offerID,
+		OccurredAt: occurredAt,
+	}
+}
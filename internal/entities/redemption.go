@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfferStatus string
+
+const (
+	OfferStatusInProgress OfferStatus = "in_progress"
+	OfferStatusEligible   OfferStatus = "eligible"
+	OfferStatusActivated  OfferStatus = "activated"
+	OfferStatusRedeemed   OfferStatus = "redeemed"
+)
+
+type Activation struct {
+	UserID      string    // uuid
+	OfferID     string    // uuid
+	ActivatedAt time.Time // RFC3339 timestamp
+}
+
+type Redemption struct {
+	ID          string    // uuid
+	UserID      string    // uuid
+	OfferID     string    // uuid
+	AmountCents int64     // integer cents, reward value paid out
+	RedeemedAt  time.Time // RFC3339 timestamp
+}
+
+func NewActivation(userID, offerID string, activatedAt time.Time) *Activation {
+	return &Activation{
+		UserID:      userID,
+		OfferID:     offerID,
+		ActivatedAt: activatedAt,
+	}
+}
+
+func NewRedemption(userID, offerID string, amountCents int64, redeemedAt time.Time) *Redemption {
+	return &Redemption{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		OfferID:     offerID,
+		AmountCents: amountCents,
+		RedeemedAt:  redeemedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"slices"
+	"strconv"
+	"time"
+	_ "time/tzdata"
+
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+var weekdaysByName = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Schedule restricts an offer to recurring local time windows, e.g. Fri-Sun 17:00-22:00.
+// A window whose end is before its start runs past midnight into the next day.
+type Schedule struct {
+	Timezone            string         // IANA name, e.g. "America/Sao_Paulo"
+	Days                []time.Weekday // days the window starts on; empty means every day
+	StartTime           string         // "HH:MM"
+	EndTime             string         // "HH:MM"
+	ApplyToTransactions bool           // only count transactions made inside the window
+
+	// Parsed by NewSchedule; schedules built as literals parse the fields above on each use.
+	location    *time.Location
+	startMinute int
+	endMinute   int
+}
+
+func NewSchedule(timezone string, days []time.Weekday, startTime, endTime string, applyToTransactions bool) (*Schedule, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, customErrors.NewFieldMessage("unknown_timezone", "unknown timezone {0}", strconv.Quote(timezone))
+	}
+
+	startMinute, err := parseMinuteOfDay(startTime)
+	if err != nil {
+		return nil, err
+	}
+	endMinute, err := parseMinuteOfDay(endTime)
+	if err != nil {
+		return nil, err
+	}
+	if startMinute == endMinute {
+		return nil, customErrors.NewFieldMessage("schedule_times_equal", "start time and end time must differ")
+	}
+
+	return &Schedule{
+		Timezone:            timezone,
+		Days:                days,
+		StartTime:           startTime,
+		EndTime:             endTime,
+		ApplyToTransactions: applyToTransactions,
+		location:            location,
+		startMinute:         startMinute,
+		endMinute:           endMinute,
+	}, nil
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, customErrors.NewFieldMessage("invalid_time_of_day", "invalid time of day {0}, expected HH:MM", strconv.Quote(value))
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func ParseWeekday(name string) (time.Weekday, bool) {
+	weekday, ok := weekdaysByName[name]
+	return weekday, ok
+}
+
+func WeekdayName(weekday time.Weekday) string {
+	for name, day := range weekdaysByName {
+		if day == weekday {
+			return name
+		}
+	}
+	return ""
+}
+
+// Contains reports whether t falls inside one of the schedule's windows. A schedule with an
+// invalid timezone or time of day contains nothing.
+func (s *Schedule) Contains(t time.Time) bool {
+	location, startMinute, endMinute, err := s.parsed()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if startMinute < endMinute {
+		return s.runsOn(local.Weekday()) && minute >= startMinute && minute < endMinute
+	}
+
+	// Overnight window: the late part belongs to today's window, the early part to yesterday's.
+	if minute >= startMinute {
+		return s.runsOn(local.Weekday())
+	}
+	if minute < endMinute {
+		return s.runsOn(local.AddDate(0, 0, -1).Weekday())
+	}
+	return false
+}
+
+func (s *Schedule) parsed() (*time.Location, int, int, error) {
+	if s.location != nil {
+		return s.location, s.startMinute, s.endMinute, nil
+	}
+
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	startMinute, err := parseMinuteOfDay(s.StartTime)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	endMinute, err := parseMinuteOfDay(s.EndTime)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return location, startMinute, endMinute, nil
+}
+
+func (s *Schedule) runsOn(weekday time.Weekday) bool {
+	return len(s.Days) == 0 || slices.Contains(s.Days, weekday)
+}
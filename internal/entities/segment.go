@@ -0,0 +1,21 @@
+package entities
+
+import "github.com/google/uuid"
+
+type Segment struct {
+	ID          string // uuid
+	Name        string // e.g. "premium cardholders"
+	Description string
+}
+
+func NewSegment(id, name, description string) *Segment {
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	return &Segment{
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+}
@@ -0,0 +1,10 @@
+package entities
+
+type ServerState string
+
+const (
+	ServerStarting ServerState = "starting"
+	ServerServing  ServerState = "serving"
+	ServerDraining ServerState = "draining" // shutting down, in-flight requests are finishing
+	ServerStopped  ServerState = "stopped"
+)
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+type StreakPeriod string
+
+const (
+	StreakDaily   StreakPeriod = "day"
+	StreakWeekly  StreakPeriod = "week" // Monday to Sunday
+	StreakMonthly StreakPeriod = "month"
+)
+
+// Streak requires at least one matching transaction in each of Count consecutive periods.
+type Streak struct {
+	Period StreakPeriod
+	Count  int
+}
+
+func (s *Streak) periodStart(t time.Time) time.Time {
+	switch s.Period {
+	case StreakMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case StreakWeekly:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (s *Streak) previousPeriodStart(start time.Time) time.Time {
+	switch s.Period {
+	case StreakMonthly:
+		return start.AddDate(0, -1, 0)
+	case StreakWeekly:
+		return start.AddDate(0, 0, -7)
+	default:
+		return start.AddDate(0, 0, -1)
+	}
+}
+
+// CurrentStreak counts consecutive periods with a transaction, walking back from the
+// period containing now. The current period is skipped while it has no transaction
+// yet, so an ongoing streak is not broken before the user had a chance to extend it.
+// hasTransaction reports whether a matching transaction was approved within [start, end].
+func (o *Offer) CurrentStreak(hasTransaction func(start, end time.Time) bool, now time.Time) int {
+	if o.Streak == nil {
+		return 0
+	}
+	if o.Location != nil {
+		now = now.In(o.Location)
+	}
+
+	start, end := o.Streak.periodStart(now), now
+	streak := 0
+	if hasTransaction(start, end) {
+		streak++
+	}
+	for {
+		start, end = o.Streak.previousPeriodStart(start), start.Add(-time.Nanosecond)
+		if !hasTransaction(start, end) {
+			return streak
+		}
+		streak++
+	}
+}
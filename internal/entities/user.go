@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+type User struct {
+	ID         string            // uuid
+	Country    string            // ISO 3166-1 alpha-2, e.g. "BR"
+	SignupDate time.Time         // RFC3339 timestamp
+	CardTier   string            // e.g. "gold"
+	Tags       map[string]string // arbitrary key/value attributes
+}
+
+func NewUser(id, country string, signupDate time.Time, cardTier string, tags map[string]string) *User {
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+
+	return &User{
+		ID:         id,
+		Country:    country,
+		SignupDate: signupDate,
+		CardTier:   cardTier,
+		Tags:       tags,
+	}
+}
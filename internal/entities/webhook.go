@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending      WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered    WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDeadLettered WebhookDeliveryStatus = "dead_lettered"
+)
+
+type WebhookSubscription struct {
+	ID         string // uuid
+	MerchantID string // uuid, events for this merchant's offers are delivered
+	URL        string
+	Secret     string           // HMAC-SHA256 key, generated when empty
+	EventTypes []OfferEventType // empty means every event type
+}
+
+type WebhookDelivery struct {
+	ID             string // uuid
+	SubscriptionID string // uuid
+	EventID        string // uuid
+	EventType      OfferEventType
+	Payload        []byte // JSON body, signed as-is
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewWebhookSubscription(id, merchantID, url, secret string, eventTypes []OfferEventType) *WebhookSubscription {
+	if id == "" {
+		id = uuid.New().String()
+	}
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+
+	return &WebhookSubscription{
+		ID:         id,
+		MerchantID: merchantID,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	}
+}
+
+func newWebhookSecret() string {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return "whsec_" + hex.EncodeToString(key)
+}
+
+func (s *WebhookSubscription) Accepts(eventType OfferEventType) bool {
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType)
+}
+
+// Sign returns the hex HMAC-SHA256 of "<unix timestamp>.<body>". Including the timestamp
+// lets receivers reject replayed requests.
+func (s *WebhookSubscription) Sign(timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func NewWebhookDelivery(subscriptionID string, event *OfferEvent, payload []byte, createdAt time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             uuid.New().String(),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  createdAt,
+		CreatedAt:      createdAt,
+	}
+}
+
+// RetryBackoff doubles the delay after every failed attempt, starting at base and capped at limit.
+func RetryBackoff(attempts int, base, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type WindowType string
+
+const (
+	WindowRolling         WindowType = "rolling"           // last LookbackDays days up to now
+	WindowCalendarMonth   WindowType = "calendar_month"    // from the first day of the current month up to now
+	WindowPreviousWeek    WindowType = "previous_week"     // the whole previous Monday-Sunday week
+	WindowSinceOfferStart WindowType = "since_offer_start" // from StartsAt up to now
+)
+
+// Window is an inclusive [Start, End] range of approval times.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && !t.After(w.End)
+}
+
+// LookbackWindow computes the transaction window for the offer at now. Calendar
+// boundaries are taken in the offer's Location, or in now's zone when unset.
+func (o *Offer) LookbackWindow(now time.Time) Window {
+	if o.Location != nil {
+		now = now.In(o.Location)
+	}
+
+	switch o.WindowType {
+	case WindowCalendarMonth:
+		return Window{
+			Start: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+			End:   now,
+		}
+	case WindowPreviousWeek:
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		thisWeekStart := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, now.Location())
+		return Window{
+			Start: thisWeekStart.AddDate(0, 0, -7),
+			End:   thisWeekStart.Add(-time.Nanosecond),
+		}
+	case WindowSinceOfferStart:
+		return Window{
+			Start: o.StartsAt.In(now.Location()),
+			End:   now,
+		}
+	default:
+		return Window{
+			Start: now.AddDate(0, 0, -o.LookbackDays),
+			End:   now,
+		}
+	}
+}
+
+func (o *Offer) WindowDescription() string {
+	switch o.WindowType {
+	case WindowCalendarMonth:
+		return "this calendar month"
+	case WindowPreviousWeek:
+		return "in the previous week"
+	case WindowSinceOfferStart:
+		return "since offer start"
+	default:
+		return fmt.Sprintf("in last %d days", o.LookbackDays)
+	}
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NotFoundError reports that a record the request refers to does not exist.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+func NewNotFoundError(message string) error {
+	return &NotFoundError{Message: message}
+}
+
+// ConflictError reports that the request is valid but clashes with the current state,
+// e.g. redeeming an offer twice.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func NewConflictError(message string) error {
+	return &ConflictError{Message: message}
+}
+
+// ValidationError reports invalid input. Errors holds a message per field, keyed by its
+// JSON name, e.g. "mcc_whitelist[0]". Messages holds the same fields when they can be
+// translated.
+type ValidationError struct {
+	Message  string
+	Errors   map[string]string
+	Messages map[string]FieldMessage
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func NewValidationError(message string, errors map[string]string) error {
+	return &ValidationError{Message: message, Errors: errors}
+}
+
+// NewFieldValidationError reports invalid input whose field errors can be shown in the
+// client's language.
+func NewFieldValidationError(message string, messages map[string]FieldMessage) error {
+	errors := make(map[string]string, len(messages))
+	for field, fieldMessage := range messages {
+		errors[field] = fieldMessage.Text
+	}
+	return &ValidationError{Message: message, Errors: errors, Messages: messages}
+}
+
+// FieldMessage is a field error that can be translated. Key names its translations in the
+// helpers catalog and Params fill their {0}, {1}... placeholders; Text is the message in
+// English, used where no translation exists.
+type FieldMessage struct {
+	Key    string
+	Params []string
+	Text   string
+}
+
+func (m FieldMessage) Error() string {
+	return m.Text
+}
+
+// NewFieldMessage builds a message from its English text, e.g.
+// NewFieldMessage("unknown_mcc", "unknown mcc {0}", "9999").
+func NewFieldMessage(key, text string, params ...string) FieldMessage {
+	for i, param := range params {
+		text = strings.ReplaceAll(text, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return FieldMessage{Key: key, Params: params, Text: text}
+}
@@ -2,20 +2,30 @@ package errors
 
 import "net/http"
 
+// HttpError is a failed request's status and client-facing message, written as a Problem. Use
+// cases return domain errors instead; the error handler maps them to an HttpError.
 type HttpError struct {
-	StatusCode int               `json:"statusCode"`
-	Message    string            `json:"message"`
-	Errors     map[string]string `json:"errors,omitempty"`
+	StatusCode int
+	Message    string
+	Errors     map[string]string
 }
 
 func (e *HttpError) Error() string {
 	return e.Message
 }
 
-func NewBadRequestError(message string, errors map[string]string) error {
-	return &HttpError{StatusCode: http.StatusBadRequest, Message: message, Errors: errors}
-}
-
 func NewInternalServerError(message string) error {
 	return &HttpError{StatusCode: http.StatusInternalServerError, Message: message}
 }
+
+func NewMethodNotAllowedError(message string) error {
+	return &HttpError{StatusCode: http.StatusMethodNotAllowed, Message: message}
+}
+
+func NewPayloadTooLargeError(message string) error {
+	return &HttpError{StatusCode: http.StatusRequestEntityTooLarge, Message: message}
+}
+
+func NewGatewayTimeoutError(message string) error {
+	return &HttpError{StatusCode: http.StatusGatewayTimeout, Message: message}
+}
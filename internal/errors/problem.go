@@ -0,0 +1,46 @@
+package errors
+
+import "net/http"
+
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the type of every problem; the suffix names the kind of failure,
+// e.g. "urn:eligible-offers:problem:validation-error".
+const problemTypeBase = "urn:eligible-offers:problem:"
+
+var problemTypes = map[int]string{
+	http.StatusBadRequest:            "validation-error",
+	http.StatusNotFound:              "not-found",
+	http.StatusMethodNotAllowed:      "method-not-allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "payload-too-large",
+	http.StatusInternalServerError:   "internal-error",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// Problem is an RFC 7807 problem details response body. Errors is an extension holding a
+// message per invalid field.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+// NewProblem describes err as a problem. instance identifies the failed request.
+func NewProblem(err *HttpError, instance string) *Problem {
+	problemType := "about:blank"
+	if name, exists := problemTypes[err.StatusCode]; exists {
+		problemType = problemTypeBase + name
+	}
+	return &Problem{
+		Type:     problemType,
+		Title:    http.StatusText(err.StatusCode),
+		Status:   err.StatusCode,
+		Detail:   err.Message,
+		Instance: instance,
+		Errors:   err.Errors,
+	}
+}
@@ -1,13 +1,20 @@
 package errors
 
+// ServiceError is an unexpected failure inside a use case. Message is safe to return to clients;
+// Cause keeps the underlying error for logs and for errors.Is checks.
 type ServiceError struct {
 	Message string
+	Cause   error
 }
 
 func (e *ServiceError) Error() string {
 	return e.Message
 }
 
-func NewServiceError(message string) error {
-	return &ServiceError{Message: message}
+func (e *ServiceError) Unwrap() error {
+	return e.Cause
+}
+
+func NewServiceError(message string, cause error) error {
+	return &ServiceError{Message: message, Cause: cause}
 }
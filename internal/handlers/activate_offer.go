@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type ActivateOfferHandler struct {
+	activateOfferUseCase *use_cases.ActivateOfferUseCase
+}
+
+func NewActivateOfferHandler(activateOfferUseCase *use_cases.ActivateOfferUseCase) *ActivateOfferHandler {
+	return &ActivateOfferHandler{
+		activateOfferUseCase: activateOfferUseCase,
+	}
+}
+
+func (h *ActivateOfferHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	now, err := parseNowParam(r)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.activateOfferUseCase.Execute(r.Context(), &dtos.ActivateOfferRequest{
+		UserID:  chi.URLParam(r, "user_id"),
+		OfferID: chi.URLParam(r, "offer_id"),
+		Now:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
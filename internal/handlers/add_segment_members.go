@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type AddSegmentMembersHandler struct {
+	addSegmentMembersUseCase *use_cases.AddSegmentMembersUseCase
+}
+
+func NewAddSegmentMembersHandler(addSegmentMembersUseCase *use_cases.AddSegmentMembersUseCase) *AddSegmentMembersHandler {
+	return &AddSegmentMembersHandler{
+		addSegmentMembersUseCase: addSegmentMembersUseCase,
+	}
+}
+
+// Handle accepts either a JSON body ({"user_ids": [...]}) or a text/csv upload
+// whose first column holds user IDs, with an optional "user_id" header row.
+func (h *AddSegmentMembersHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var request dtos.AddSegmentMembersRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		userIDs, err := readCSVUserIDs(r.Body)
+		if err != nil {
+			return bodyError(err, "Invalid CSV body")
+		}
+		request.UserIDs = userIDs
+	} else if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return bodyError(err, "Invalid request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
+	}
+
+	request.SegmentID = chi.URLParam(r, "segment_id")
+	request.Replace = r.URL.Query().Get("replace") == "true"
+
+	response, err := h.addSegmentMembersUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
+
+func readCSVUserIDs(body io.Reader) ([]string, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	userIDs := make([]string, 0)
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		userID := strings.TrimSpace(record[0])
+		if userID == "" || (line == 0 && strings.EqualFold(userID, "user_id")) {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+// bodyError reports a body that could not be read or decoded. Bodies cut off by the
+// MaxBodySize limit, such as chunked ones with no Content-Length, get a 413; anything else
+// is a 400 with message.
+func bodyError(err error, message string) error {
+	if errors.As(err, new(*http.MaxBytesError)) {
+		return httpErrors.NewPayloadTooLargeError("Request body too large")
+	}
+	return httpErrors.NewValidationError(message, nil)
+}
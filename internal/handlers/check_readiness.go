@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type CheckReadinessHandler struct {
+	checkReadinessUseCase *use_cases.CheckReadinessUseCase
+}
+
+func NewCheckReadinessHandler(checkReadinessUseCase *use_cases.CheckReadinessUseCase) *CheckReadinessHandler {
+	return &CheckReadinessHandler{
+		checkReadinessUseCase: checkReadinessUseCase,
+	}
+}
+
+// Handle returns 503 with the per-component status while the server is starting, draining
+// or has a component down.
+func (h *CheckReadinessHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.checkReadinessUseCase.Execute(r.Context())
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if !result.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type DeleteSegmentHandler struct {
+	deleteSegmentUseCase *use_cases.DeleteSegmentUseCase
+}
+
+func NewDeleteSegmentHandler(deleteSegmentUseCase *use_cases.DeleteSegmentUseCase) *DeleteSegmentHandler {
+	return &DeleteSegmentHandler{
+		deleteSegmentUseCase: deleteSegmentUseCase,
+	}
+}
+
+func (h *DeleteSegmentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	err := h.deleteSegmentUseCase.Execute(r.Context(), &dtos.DeleteSegmentRequest{
+		SegmentID: chi.URLParam(r, "segment_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type DeleteWebhookSubscriptionHandler struct {
+	deleteWebhookSubscriptionUseCase *use_cases.DeleteWebhookSubscriptionUseCase
+}
+
+func NewDeleteWebhookSubscriptionHandler(deleteWebhookSubscriptionUseCase *use_cases.DeleteWebhookSubscriptionUseCase) *DeleteWebhookSubscriptionHandler {
+	return &DeleteWebhookSubscriptionHandler{
+		deleteWebhookSubscriptionUseCase: deleteWebhookSubscriptionUseCase,
+	}
+}
+
+func (h *DeleteWebhookSubscriptionHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	err := h.deleteWebhookSubscriptionUseCase.Execute(r.Context(), &dtos.DeleteWebhookSubscriptionRequest{
+		SubscriptionID: chi.URLParam(r, "subscription_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+)
+
+type GetConfigHandler struct {
+	config *config.Config
+}
+
+func NewGetConfigHandler(config *config.Config) *GetConfigHandler {
+	return &GetConfigHandler{
+		config: config,
+	}
+}
+
+// Handle returns the effective config with secrets redacted.
+func (h *GetConfigHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.config.Redacted())
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+)
+
+// hiddenDebugVars are expvar variables not served: cmdline holds os.Args, whose flags may carry
+// credentials such as -storage-dsn.
+var hiddenDebugVars = map[string]bool{"cmdline": true}
+
+type GetDebugVarsHandler struct {
+	vars map[string]expvar.Var
+}
+
+// NewGetDebugVarsHandler serves the process's expvar variables plus vars, which are kept out
+// of the global expvar registry so each router can publish its own.
+func NewGetDebugVarsHandler(vars map[string]expvar.Var) *GetDebugVarsHandler {
+	return &GetDebugVarsHandler{
+		vars: vars,
+	}
+}
+
+// Handle returns the variables as a JSON object, like expvar.Handler.
+func (h *GetDebugVarsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	response := make(map[string]json.RawMessage)
+	expvar.Do(func(kv expvar.KeyValue) {
+		if !hiddenDebugVars[kv.Key] {
+			response[kv.Key] = json.RawMessage(kv.Value.String())
+		}
+	})
+	for name, value := range h.vars {
+		response[name] = json.RawMessage(value.String())
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
@@ -3,19 +3,17 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"time"
 
 	"github.com/Drinnn/eligible-offers-api/internal/dtos"
-	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
 	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
 	"github.com/go-chi/chi"
 )
 
 type GetEligibleOffersHandler struct {
-	getEligibleOffersUseCase *use_cases.GetEligibleOffersUseCase
+	getEligibleOffersUseCase use_cases.GetEligibleOffersExecutor
 }
 
-func NewGetEligibleOffersHandler(getEligibleOffersUseCase *use_cases.GetEligibleOffersUseCase) *GetEligibleOffersHandler {
+func NewGetEligibleOffersHandler(getEligibleOffersUseCase use_cases.GetEligibleOffersExecutor) *GetEligibleOffersHandler {
 	return &GetEligibleOffersHandler{
 		getEligibleOffersUseCase: getEligibleOffersUseCase,
 	}
@@ -24,19 +22,12 @@ func NewGetEligibleOffersHandler(getEligibleOffersUseCase *use_cases.GetEligible
 func (h *GetEligibleOffersHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	userID := chi.URLParam(r, "user_id")
 
-	nowStr := r.URL.Query().Get("now")
-	now := time.Now()
-	if nowStr != "" {
-		parsed, err := time.Parse(time.RFC3339, nowStr)
-		if err != nil {
-			return httpErrors.NewBadRequestError("Invalid now parameter", map[string]string{
-				"now": "invalid time format. expected RFC3339 timestamp",
-			})
-		}
-		now = parsed
+	now, err := parseNowParam(r)
+	if err != nil {
+		return err
 	}
 
-	result, err := h.getEligibleOffersUseCase.Execute(&dtos.GetEligibleOffersRequest{
+	result, err := h.getEligibleOffersUseCase.Execute(r.Context(), &dtos.GetEligibleOffersRequest{
 		UserID: userID,
 		Now:    now,
 	})
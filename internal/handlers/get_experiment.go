@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type GetExperimentHandler struct {
+	getExperimentUseCase *use_cases.GetExperimentUseCase
+}
+
+func NewGetExperimentHandler(getExperimentUseCase *use_cases.GetExperimentUseCase) *GetExperimentHandler {
+	return &GetExperimentHandler{
+		getExperimentUseCase: getExperimentUseCase,
+	}
+}
+
+func (h *GetExperimentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.getExperimentUseCase.Execute(r.Context(), &dtos.GetExperimentRequest{
+		ExperimentID: chi.URLParam(r, "experiment_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
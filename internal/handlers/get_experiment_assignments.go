@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type GetExperimentAssignmentsHandler struct {
+	getExperimentAssignmentsUseCase *use_cases.GetExperimentAssignmentsUseCase
+}
+
+func NewGetExperimentAssignmentsHandler(getExperimentAssignmentsUseCase *use_cases.GetExperimentAssignmentsUseCase) *GetExperimentAssignmentsHandler {
+	return &GetExperimentAssignmentsHandler{
+		getExperimentAssignmentsUseCase: getExperimentAssignmentsUseCase,
+	}
+}
+
+func (h *GetExperimentAssignmentsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.getExperimentAssignmentsUseCase.Execute(r.Context(), &dtos.GetExperimentAssignmentsRequest{
+		ExperimentID: chi.URLParam(r, "experiment_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type GetOfferBudgetHandler struct {
+	getOfferBudgetUseCase *use_cases.GetOfferBudgetUseCase
+}
+
+func NewGetOfferBudgetHandler(getOfferBudgetUseCase *use_cases.GetOfferBudgetUseCase) *GetOfferBudgetHandler {
+	return &GetOfferBudgetHandler{
+		getOfferBudgetUseCase: getOfferBudgetUseCase,
+	}
+}
+
+func (h *GetOfferBudgetHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	now, err := parseNowParam(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.getOfferBudgetUseCase.Execute(r.Context(), &dtos.GetOfferBudgetRequest{
+		OfferID: chi.URLParam(r, "offer_id"),
+		Now:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
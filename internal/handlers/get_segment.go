@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type GetSegmentHandler struct {
+	getSegmentUseCase *use_cases.GetSegmentUseCase
+}
+
+func NewGetSegmentHandler(getSegmentUseCase *use_cases.GetSegmentUseCase) *GetSegmentHandler {
+	return &GetSegmentHandler{
+		getSegmentUseCase: getSegmentUseCase,
+	}
+}
+
+func (h *GetSegmentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.getSegmentUseCase.Execute(r.Context(), &dtos.GetSegmentRequest{
+		SegmentID: chi.URLParam(r, "segment_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type GetUserProfileHandler struct {
+	getUserProfileUseCase *use_cases.GetUserProfileUseCase
+}
+
+func NewGetUserProfileHandler(getUserProfileUseCase *use_cases.GetUserProfileUseCase) *GetUserProfileHandler {
+	return &GetUserProfileHandler{
+		getUserProfileUseCase: getUserProfileUseCase,
+	}
+}
+
+func (h *GetUserProfileHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.getUserProfileUseCase.Execute(r.Context(), &dtos.GetUserProfileRequest{
+		UserID: chi.URLParam(r, "user_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
@@ -10,31 +10,51 @@ import (
 	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
 )
 
+// IngestObserver records how many transactions of each batch were stored, were already
+// stored, or were rejected by validation.
+type IngestObserver interface {
+	ObserveTransactions(ingested, duplicated, rejected int)
+}
+
 type IngestTransactionsHandler struct {
 	ingestTransactionsUseCase *use_cases.IngestTransactionsUseCase
+	ingestObserver            IngestObserver // optional
 }
 
-func NewIngestTransactionsHandler(ingestTransactionsUseCase *use_cases.IngestTransactionsUseCase) *IngestTransactionsHandler {
+func NewIngestTransactionsHandler(ingestTransactionsUseCase *use_cases.IngestTransactionsUseCase, ingestObserver IngestObserver) *IngestTransactionsHandler {
 	return &IngestTransactionsHandler{
 		ingestTransactionsUseCase: ingestTransactionsUseCase,
+		ingestObserver:            ingestObserver,
 	}
 }
 
 func (h *IngestTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	var request dtos.IngestTransactionsRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		return httpErrors.NewBadRequestError("Invalid request body", nil)
+		return bodyError(err, "Invalid request body")
 	}
 
 	if err := request.Validate(); err != nil {
-		errorResponse := helpers.FormatValidationErrors(err)
-		return httpErrors.NewBadRequestError("Invalid request body", errorResponse.Errors)
+		if h.ingestObserver != nil {
+			h.ingestObserver.ObserveTransactions(0, 0, len(request.Transactions))
+		}
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
 	}
 
-	response, err := h.ingestTransactionsUseCase.Execute(&request)
+	now, err := parseNowParam(r)
 	if err != nil {
 		return err
 	}
+	request.Now = now
+
+	response, err := h.ingestTransactionsUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+	if h.ingestObserver != nil {
+		h.ingestObserver.ObserveTransactions(response.Inserted, len(request.Transactions)-response.Inserted, 0)
+	}
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
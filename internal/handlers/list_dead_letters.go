@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type ListDeadLettersHandler struct {
+	listDeadLettersUseCase *use_cases.ListDeadLettersUseCase
+}
+
+func NewListDeadLettersHandler(listDeadLettersUseCase *use_cases.ListDeadLettersUseCase) *ListDeadLettersHandler {
+	return &ListDeadLettersHandler{
+		listDeadLettersUseCase: listDeadLettersUseCase,
+	}
+}
+
+func (h *ListDeadLettersHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.listDeadLettersUseCase.Execute(r.Context())
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
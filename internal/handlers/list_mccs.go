@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type ListMCCsHandler struct {
+	listMCCsUseCase *use_cases.ListMCCsUseCase
+}
+
+func NewListMCCsHandler(listMCCsUseCase *use_cases.ListMCCsUseCase) *ListMCCsHandler {
+	return &ListMCCsHandler{
+		listMCCsUseCase: listMCCsUseCase,
+	}
+}
+
+func (h *ListMCCsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.listMCCsUseCase.Execute(r.Context(), &dtos.ListMCCsRequest{
+		Category: r.URL.Query().Get("category"),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
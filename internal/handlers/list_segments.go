@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type ListSegmentsHandler struct {
+	listSegmentsUseCase *use_cases.ListSegmentsUseCase
+}
+
+func NewListSegmentsHandler(listSegmentsUseCase *use_cases.ListSegmentsUseCase) *ListSegmentsHandler {
+	return &ListSegmentsHandler{
+		listSegmentsUseCase: listSegmentsUseCase,
+	}
+}
+
+func (h *ListSegmentsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.listSegmentsUseCase.Execute(r.Context())
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
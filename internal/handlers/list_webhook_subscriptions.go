@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type ListWebhookSubscriptionsHandler struct {
+	listWebhookSubscriptionsUseCase *use_cases.ListWebhookSubscriptionsUseCase
+}
+
+func NewListWebhookSubscriptionsHandler(listWebhookSubscriptionsUseCase *use_cases.ListWebhookSubscriptionsUseCase) *ListWebhookSubscriptionsHandler {
+	return &ListWebhookSubscriptionsHandler{
+		listWebhookSubscriptionsUseCase: listWebhookSubscriptionsUseCase,
+	}
+}
+
+func (h *ListWebhookSubscriptionsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	result, err := h.listWebhookSubscriptionsUseCase.Execute(r.Context())
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+)
+
+type LivenessHandler struct{}
+
+func NewLivenessHandler() *LivenessHandler {
+	return &LivenessHandler{}
+}
+
+// Handle reports that the process is up. It checks no dependencies, so a slow or failing
+// backend makes the server unready rather than getting it restarted.
+func (h *LivenessHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dtos.LivenessResponse{Status: "ok"})
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+// parseNowParam reads the optional RFC3339 "now" query parameter, defaulting to server time.
+func parseNowParam(r *http.Request) (time.Time, error) {
+	nowStr := r.URL.Query().Get("now")
+	if nowStr == "" {
+		return time.Now(), nil
+	}
+
+	now, err := time.Parse(time.RFC3339, nowStr)
+	if err != nil {
+		return time.Time{}, httpErrors.NewFieldValidationError("Invalid now parameter", map[string]httpErrors.FieldMessage{
+			"now": httpErrors.NewFieldMessage("invalid_timestamp", "invalid time format. expected RFC3339 timestamp"),
+		})
+	}
+	return now, nil
+}
+
+// parseBoolParam reads an optional boolean query parameter in any form strconv.ParseBool
+// accepts, e.g. "true", "1" or "false", defaulting to false.
+func parseBoolParam(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, httpErrors.NewFieldValidationError("Invalid "+name+" parameter", map[string]httpErrors.FieldMessage{
+			name: httpErrors.NewFieldMessage("invalid_bool", "invalid boolean. expected true or false"),
+		})
+	}
+	return parsed, nil
+}
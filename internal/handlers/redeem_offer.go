@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type RedeemOfferHandler struct {
+	redeemOfferUseCase *use_cases.RedeemOfferUseCase
+}
+
+func NewRedeemOfferHandler(redeemOfferUseCase *use_cases.RedeemOfferUseCase) *RedeemOfferHandler {
+	return &RedeemOfferHandler{
+		redeemOfferUseCase: redeemOfferUseCase,
+	}
+}
+
+func (h *RedeemOfferHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var request dtos.RedeemOfferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		return bodyError(err, "Invalid request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
+	}
+
+	now, err := parseNowParam(r)
+	if err != nil {
+		return err
+	}
+	request.UserID = chi.URLParam(r, "user_id")
+	request.OfferID = chi.URLParam(r, "offer_id")
+	request.Now = now
+
+	response, err := h.redeemOfferUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type ReplayDeadLettersHandler struct {
+	replayDeadLettersUseCase *use_cases.ReplayDeadLettersUseCase
+}
+
+func NewReplayDeadLettersHandler(replayDeadLettersUseCase *use_cases.ReplayDeadLettersUseCase) *ReplayDeadLettersHandler {
+	return &ReplayDeadLettersHandler{
+		replayDeadLettersUseCase: replayDeadLettersUseCase,
+	}
+}
+
+func (h *ReplayDeadLettersHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var request dtos.ReplayDeadLettersRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		return bodyError(err, "Invalid request body")
+	}
+
+	now, err := parseNowParam(r)
+	if err != nil {
+		return err
+	}
+	request.Now = now
+
+	response, err := h.replayDeadLettersUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type UpsertExperimentHandler struct {
+	upsertExperimentUseCase *use_cases.UpsertExperimentUseCase
+}
+
+func NewUpsertExperimentHandler(upsertExperimentUseCase *use_cases.UpsertExperimentUseCase) *UpsertExperimentHandler {
+	return &UpsertExperimentHandler{
+		upsertExperimentUseCase: upsertExperimentUseCase,
+	}
+}
+
+func (h *UpsertExperimentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var request dtos.UpsertExperimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return bodyError(err, "Invalid request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
+	}
+
+	response, err := h.upsertExperimentUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
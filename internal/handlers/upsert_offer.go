@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
 	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
 	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
 	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
 )
 
@@ -23,31 +26,88 @@ func NewUpsertOfferHandler(upsertOfferUseCase *use_cases.UpsertOfferUseCase) *Up
 func (h *UpsertOfferHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	var request dtos.UpsertOfferRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		return httpErrors.NewBadRequestError("Invalid request body", nil)
+		return bodyError(err, "Invalid request body")
 	}
+	logging.AddAttrs(r.Context(), slog.String("offer_id", request.ID))
 
 	if err := request.Validate(); err != nil {
-		errorResponse := helpers.FormatValidationErrors(err)
-		return httpErrors.NewBadRequestError("Invalid request body", errorResponse.Errors)
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
 	}
 
-	offer, err := h.upsertOfferUseCase.Execute(&request)
+	now, err := parseNowParam(r)
 	if err != nil {
 		return err
 	}
+	request.Now = now
+	request.ValidateOnly, err = parseBoolParam(r, "validate_only")
+	if err != nil {
+		return err
+	}
+
+	result, err := h.upsertOfferUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+	offer := result.Offer
 
 	response := dtos.UpsertOfferResponse{
 		ID:           offer.ID,
 		MerchantID:   offer.MerchantID,
-		MCCWhitelist: offer.MCCWhitelist,
+		MCCWhitelist: offer.MCCSelectors,
+		MCCCodes:     offer.MCCWhitelist,
 		Active:       offer.Active,
 		MinTxnCount:  offer.MinTxnCount,
 		LookbackDays: offer.LookbackDays,
 		StartsAt:     offer.StartsAt,
 		EndsAt:       offer.EndsAt,
+		WindowType:   string(offer.WindowType),
+
+		MinDistinctMerchants: offer.MinDistinctMerchants,
+		SingleUse:            offer.SingleUse,
+		MaxRedemptions:       offer.MaxRedemptions,
+		BudgetCents:          offer.BudgetCents,
+		IncludeSegments:      offer.IncludeSegments,
+		ExcludeSegments:      offer.ExcludeSegments,
+
+		Warnings: helpers.TranslateMessages(result.Warnings, r.Header.Get("Accept-Language")),
+	}
+	if offer.Streak != nil {
+		response.Streak = &dtos.StreakDto{
+			Period: string(offer.Streak.Period),
+			Count:  offer.Streak.Count,
+		}
+	}
+	if offer.Location != nil {
+		response.Timezone = offer.Location.String()
+	}
+	for _, predicate := range offer.AttributePredicates {
+		response.AttributePredicates = append(response.AttributePredicates, dtos.AttributePredicateDto{
+			Attribute: predicate.Attribute,
+			Operator:  predicate.Operator,
+			Values:    predicate.Values,
+		})
+	}
+	if offer.Schedule != nil {
+		days := make([]string, 0, len(offer.Schedule.Days))
+		for _, day := range offer.Schedule.Days {
+			days = append(days, entities.WeekdayName(day))
+		}
+		response.Schedule = &dtos.ScheduleDto{
+			Timezone:            offer.Schedule.Timezone,
+			Days:                days,
+			StartTime:           offer.Schedule.StartTime,
+			EndTime:             offer.Schedule.EndTime,
+			ApplyToTransactions: offer.Schedule.ApplyToTransactions,
+		}
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	// A dry run returns the offer as it would be saved.
+	if request.ValidateOnly {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
 	json.NewEncoder(w).Encode(response)
 
 	return nil
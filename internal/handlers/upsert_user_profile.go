@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type UpsertUserProfileHandler struct {
+	upsertUserProfileUseCase *use_cases.UpsertUserProfileUseCase
+}
+
+func NewUpsertUserProfileHandler(upsertUserProfileUseCase *use_cases.UpsertUserProfileUseCase) *UpsertUserProfileHandler {
+	return &UpsertUserProfileHandler{
+		upsertUserProfileUseCase: upsertUserProfileUseCase,
+	}
+}
+
+func (h *UpsertUserProfileHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var request dtos.UpsertUserProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return bodyError(err, "Invalid request body")
+	}
+	logging.AddAttrs(r.Context(), slog.String("user_id", request.ID))
+
+	if err := request.Validate(); err != nil {
+		errorResponse := helpers.FormatValidationErrors(err, r.Header.Get("Accept-Language"))
+		return httpErrors.NewValidationError("Invalid request body", errorResponse.Errors)
+	}
+
+	response, err := h.upsertUserProfileUseCase.Execute(r.Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+
+	return nil
+}
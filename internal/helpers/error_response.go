@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -8,30 +10,26 @@ type ErrorResponse struct {
 	Errors map[string]string `json:"errors"`
 }
 
-func FormatValidationErrors(err error) ErrorResponse {
+// FormatValidationErrors keys each error by the field's JSON path, e.g. "transactions[3].mcc",
+// with a message in the first supported language of acceptLanguage.
+func FormatValidationErrors(err error, acceptLanguage string) ErrorResponse {
 	errors := make(map[string]string)
 
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		trans := Translator(acceptLanguage)
 		for _, e := range validationErrors {
-			fieldName := e.Field()
-			errors[fieldName] = getErrorMessage(e)
+			errors[fieldPath(e)] = e.Translate(trans)
 		}
 	}
 
 	return ErrorResponse{Errors: errors}
 }
 
-func getErrorMessage(e validator.FieldError) string {
-	switch e.Tag() {
-	case "required":
-		return e.Field() + " is required"
-	case "gt":
-		return e.Field() + " must be greater than " + e.Param()
-	case "len":
-		return e.Field() + " must be exactly " + e.Param() + " characters"
-	case "numeric":
-		return e.Field() + " must contain only numeric characters"
-	default:
-		return e.Field() + " is invalid"
+// fieldPath drops the request struct's name from the error's namespace.
+func fieldPath(e validator.FieldError) string {
+	_, path, found := strings.Cut(e.Namespace(), ".")
+	if !found {
+		return e.Field()
 	}
+	return path
 }
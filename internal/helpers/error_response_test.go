@@ -0,0 +1,129 @@
+package helpers_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+)
+
+type validatable interface {
+	Validate() error
+}
+
+// invalidRequests break every validate tag used in the dtos package; each error is listed
+// under the JSON path it is expected at.
+var invalidRequests = []struct {
+	request validatable
+	fields  []string
+}{
+	{
+		request: &dtos.IngestTransactionsRequest{Transactions: []dtos.TransactionDto{
+			{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", MCC: "5812", AmountCents: 100, ApprovedAt: time.Now()},
+			{ID: "txn-2", UserID: "user-1", MerchantID: "merchant-1", MCC: "58a2", AmountCents: -5, ApprovedAt: time.Now()},
+			{ID: "txn-3", MerchantID: "merchant-1", MCC: "581", AmountCents: 100, ApprovedAt: time.Now()},
+		}},
+		fields: []string{"transactions[1].mcc", "transactions[1].amount_cents", "transactions[2].user_id", "transactions[2].mcc"},
+	},
+	{
+		request: &dtos.UpsertOfferRequest{
+			MCCWhitelist: []string{"5812"},
+			MinTxnCount:  1,
+			WindowType:   "fortnight",
+			StartsAt:     time.Now(),
+			EndsAt:       time.Now().Add(time.Hour),
+			Schedule:     &dtos.ScheduleDto{Timezone: "UTC", StartTime: "25:00", EndTime: "18:00"},
+		},
+		fields: []string{"merchant_id", "window_type", "schedule.start_time"},
+	},
+	{
+		request: &dtos.UpsertExperimentRequest{OfferID: "offer-1", ControlPercent: -1, TreatmentPercent: 101},
+		fields:  []string{"control_percent", "treatment_percent"},
+	},
+	{
+		request: &dtos.UpsertWebhookSubscriptionRequest{MerchantID: "merchant-1", URL: "not a url"},
+		fields:  []string{"url"},
+	},
+	{
+		request: &dtos.UpsertUserProfileRequest{ID: "user-1", Country: "Brazil", Tags: map[string]string{"": "vip"}},
+		fields:  []string{"country", "tags[]"},
+	},
+}
+
+func TestFormatValidationErrors_KeysByJSONPathInEachLocale(t *testing.T) {
+	for _, acceptLanguage := range []string{"", "en-US,en;q=0.9", "pt-BR,pt;q=0.9"} {
+		for _, tt := range invalidRequests {
+			// Given: A request that fails validation
+			err := tt.request.Validate()
+			if err == nil {
+				t.Fatalf("expected %T to be invalid", tt.request)
+			}
+
+			// When: We format the errors for the client's language
+			response := helpers.FormatValidationErrors(err, acceptLanguage)
+
+			// Then: Each invalid field is keyed by its JSON path
+			if len(response.Errors) != len(tt.fields) {
+				t.Errorf("expected errors for %v, got %v", tt.fields, response.Errors)
+			}
+			for _, field := range tt.fields {
+				message, exists := response.Errors[field]
+				if !exists {
+					t.Errorf("expected an error for %s, got %v", field, response.Errors)
+					continue
+				}
+
+				// And: Every tag has a translated message rather than the validator's raw error
+				if strings.HasPrefix(message, "Key: ") {
+					t.Errorf("expected a translated message for %s in %q, got %q", field, acceptLanguage, message)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatValidationErrors_TranslatesToPreferredLanguage(t *testing.T) {
+	// Given: A request missing a required field
+	err := (&dtos.UpsertExperimentRequest{}).Validate()
+
+	// When: We format the errors for an English and a Brazilian Portuguese client
+	english := helpers.FormatValidationErrors(err, "en")
+	portuguese := helpers.FormatValidationErrors(err, "fr-FR, pt-BR;q=0.8")
+
+	// Then: The message uses the first supported language
+	if english.Errors["offer_id"] != "offer_id is a required field" {
+		t.Errorf("expected an English message, got %q", english.Errors["offer_id"])
+	}
+	if portuguese.Errors["offer_id"] != "offer_id é um campo obrigatório" {
+		t.Errorf("expected a Portuguese message, got %q", portuguese.Errors["offer_id"])
+	}
+}
+
+func TestTranslator_MatchesTagsCaseInsensitivelyAndByLanguage(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		locale         string
+	}{
+		{"pt-BR", "pt_BR"},
+		{"pt-br", "pt_BR"},
+		{"PT_br;q=0.9", "pt_BR"},
+		{"pt", "pt_BR"},
+		{"pt-PT", "pt_BR"},
+		{"en-GB", "en"},
+		{"fr-FR, pt;q=0.8", "pt_BR"},
+		{"fr-FR, de", "en"},
+		{"", "en"},
+	}
+
+	for _, tt := range tests {
+		// When: We pick a translator for the header
+		trans := helpers.Translator(tt.acceptLanguage)
+
+		// Then: The tag matches a supported locale regardless of case and region
+		if trans.Locale() != tt.locale {
+			t.Errorf("expected %q to pick %s, got %s", tt.acceptLanguage, tt.locale, trans.Locale())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+// fieldMessages translates the field errors reported by use cases and entities, keyed by
+// their customErrors.FieldMessage key. English messages keep the text they were built with.
+var fieldMessages = map[string]map[string]string{
+	"pt_BR": {
+		"amount_required_for_budget":       "amount_cents é obrigatório para ofertas com orçamento",
+		"delivery_not_dead_lettered":       "a entrega {0} não está na fila de dead letter",
+		"ends_at_in_past":                  "ends_at está no passado",
+		"experiment_percent_total":         "control_percent + treatment_percent não deve exceder 100",
+		"invalid_bool":                     "booleano inválido. esperado true ou false",
+		"invalid_mcc_selector":             "deve ser um mcc de 4 dígitos, uma faixa de mccs ou uma categoria de mcc conhecida",
+		"invalid_time_of_day":              "horário inválido {0}, esperado HH:MM",
+		"invalid_timestamp":                "formato de data inválido. esperado timestamp RFC3339",
+		"lookback_days_required":           "lookback_days é obrigatório para janelas móveis",
+		"max_lookback_days":                "lookback_days deve ser no máximo {0}",
+		"mcc_range_empty":                  "a faixa de mccs {0} não corresponde a nenhum mcc conhecido",
+		"mcc_range_reversed":               "o início da faixa de mccs não deve ser maior que o fim",
+		"operator_requires_no_values":      "o operador {0} requer um atributo de texto e nenhum valor",
+		"operator_requires_one_date_value": "o operador {0} requer signup_date e exatamente um valor",
+		"operator_requires_one_text_value": "o operador {0} requer um atributo de texto e exatamente um valor",
+		"operator_requires_positive_days":  "o operador {0} requer um número positivo de dias",
+		"operator_requires_text_values":    "o operador {0} requer um atributo de texto e pelo menos um valor",
+		"operator_requires_timestamp":      "o operador {0} requer um timestamp RFC3339",
+		"overlapping_mccs":                 "sobrepõe mcc_whitelist[{0}] ({1}) nos mccs {2}",
+		"schedule_times_equal":             "o horário de início e o de término devem ser diferentes",
+		"unknown_attribute":                "atributo desconhecido {0}",
+		"unknown_delivery":                 "a entrega {0} não existe",
+		"unknown_mcc":                      "mcc desconhecido {0}",
+		"unknown_mcc_category":             "categoria de mcc desconhecida {0}",
+		"unknown_merchant":                 "nenhuma transação ou outra oferta se refere ao comerciante {0}",
+		"unknown_offer":                    "a oferta {0} não existe",
+		"unknown_operator":                 "operador desconhecido {0}",
+		"unknown_segment":                  "segmento desconhecido {0}",
+		"unknown_timezone":                 "fuso horário desconhecido {0}",
+		"unreachable_min_txn_count":        "min_txn_count não pode ser atingido em uma janela de {0} dias a {1} transações por dia (no máximo {2})",
+	},
+}
+
+// TranslateMessages renders each message in the first supported language of acceptLanguage,
+// keeping its English text where there is no translation.
+func TranslateMessages(messages map[string]customErrors.FieldMessage, acceptLanguage string) map[string]string {
+	if messages == nil {
+		return nil
+	}
+
+	trans := Translator(acceptLanguage)
+	translated := make(map[string]string, len(messages))
+	for field, message := range messages {
+		text, err := trans.T(message.Key, message.Params...)
+		if err != nil {
+			text = message.Text
+		}
+		translated[field] = text
+	}
+	return translated
+}
@@ -0,0 +1,36 @@
+package helpers_test
+
+import (
+	"testing"
+
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
+)
+
+func TestTranslateMessages_RendersEachLocale(t *testing.T) {
+	// Given: Field errors from the catalog and one without a translation
+	messages := map[string]customErrors.FieldMessage{
+		"mcc_whitelist[0]": customErrors.NewFieldMessage("unknown_mcc", "unknown mcc {0}", "9999"),
+		"lookback_days":    customErrors.NewFieldMessage("max_lookback_days", "lookback_days must be at most {0}", "90"),
+		"schedule":         {Text: "schedule is invalid"},
+	}
+
+	// When: We render them for an English and a Brazilian Portuguese client
+	english := helpers.TranslateMessages(messages, "en-US")
+	portuguese := helpers.TranslateMessages(messages, "pt-br")
+
+	// Then: English keeps the text the messages were built with
+	if english["mcc_whitelist[0]"] != "unknown mcc 9999" || english["lookback_days"] != "lookback_days must be at most 90" {
+		t.Errorf("expected English messages, got %v", english)
+	}
+
+	// And: Portuguese fills the translations with the same parameters
+	if portuguese["mcc_whitelist[0]"] != "mcc desconhecido 9999" || portuguese["lookback_days"] != "lookback_days deve ser no máximo 90" {
+		t.Errorf("expected Portuguese messages, got %v", portuguese)
+	}
+
+	// And: Messages without a translation fall back to their text
+	if portuguese["schedule"] != "schedule is invalid" {
+		t.Errorf("expected the untranslated text, got %q", portuguese["schedule"])
+	}
+}
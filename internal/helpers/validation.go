@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+)
+
+// customTranslations covers the tags the validator's bundled translations leave out, per locale.
+var customTranslations = map[string]map[string]string{
+	"en": {
+		"iso3166_1_alpha2": "{0} must be an ISO 3166-1 alpha-2 country code",
+	},
+	"pt_BR": {
+		"iso3166_1_alpha2": "{0} deve ser um código de país ISO 3166-1 alfa-2",
+		"datetime":         "{0} não corresponde ao formato {1}",
+	},
+}
+
+var (
+	validate   = validator.New()
+	translator = ut.New(en.New(), en.New(), pt_BR.New())
+	locales    = []string{"en", "pt_BR"}
+)
+
+func init() {
+	// Name fields after their JSON keys so errors match the request body, e.g. "transactions[3].mcc".
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	enTranslator, _ := translator.GetTranslator("en")
+	ptBRTranslator, _ := translator.GetTranslator("pt_BR")
+	if err := enTranslations.RegisterDefaultTranslations(validate, enTranslator); err != nil {
+		panic(err)
+	}
+	if err := ptBRTranslations.RegisterDefaultTranslations(validate, ptBRTranslator); err != nil {
+		panic(err)
+	}
+	for locale, translations := range customTranslations {
+		trans, _ := translator.GetTranslator(locale)
+		for tag, text := range translations {
+			registerTranslation(trans, tag, text)
+		}
+	}
+	for locale, messages := range fieldMessages {
+		trans, _ := translator.GetTranslator(locale)
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func registerTranslation(trans ut.Translator, tag, text string) {
+	err := validate.RegisterTranslation(tag, trans,
+		func(trans ut.Translator) error {
+			return trans.Add(tag, text, true)
+		},
+		func(trans ut.Translator, fe validator.FieldError) string {
+			message, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+			if err != nil {
+				return fe.Error()
+			}
+			return message
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ValidateStruct checks s against its validate tags with the shared validator.
+func ValidateStruct(s any) error {
+	return validate.Struct(s)
+}
+
+// Translator picks the first supported locale listed in an Accept-Language header, e.g.
+// "pt-BR,pt;q=0.9,en;q=0.8", falling back to English. Quality values are ignored; clients
+// list languages in order of preference.
+func Translator(acceptLanguage string) ut.Translator {
+	for _, language := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(language), ";")
+		if locale, found := matchLocale(tag); found {
+			trans, _ := translator.GetTranslator(locale)
+			return trans
+		}
+	}
+	return translator.GetFallback()
+}
+
+// matchLocale compares language tags case-insensitively and falls back to a locale of the
+// same language, so "pt-br" and "pt" both match pt_BR and "en-GB" matches en.
+func matchLocale(tag string) (string, bool) {
+	tag = strings.ReplaceAll(tag, "-", "_")
+	for _, locale := range locales {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+	language, _, _ := strings.Cut(tag, "_")
+	for _, locale := range locales {
+		if localeLanguage, _, _ := strings.Cut(locale, "_"); strings.EqualFold(localeLanguage, language) {
+			return locale, true
+		}
+	}
+	return "", false
+}
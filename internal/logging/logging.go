@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+)
+
+// New creates a logger writing JSON, or logfmt-style text when cfg.Format is "text", at the
+// configured level. Records logged with a context carry the attributes attached to it with
+// NewContext and AddAttrs, e.g. the request ID and route.
+func New(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	options := &slog.HandlerOptions{Level: cfg.SlogLevel()}
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+type contextKey struct{}
+
+// contextAttrs is shared by every context derived from the one NewContext returned, so
+// attributes added deep in a handler show up in records logged by the middleware that created it.
+type contextAttrs struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// NewContext returns a context carrying the attributes of ctx followed by attrs. Attributes
+// added to the returned context with AddAttrs are not visible from ctx.
+func NewContext(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, contextKey{}, &contextAttrs{attrs: append(Attrs(ctx), attrs...)})
+}
+
+// AddAttrs adds attrs to the context created by the nearest NewContext call. It does nothing
+// when ctx has none.
+func AddAttrs(ctx context.Context, attrs ...slog.Attr) {
+	current, ok := ctx.Value(contextKey{}).(*contextAttrs)
+	if !ok {
+		return
+	}
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	current.attrs = append(current.attrs, attrs...)
+}
+
+// Attrs returns a copy of the attributes carried by ctx.
+func Attrs(ctx context.Context) []slog.Attr {
+	current, ok := ctx.Value(contextKey{}).(*contextAttrs)
+	if !ok {
+		return nil
+	}
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	return slices.Clone(current.attrs)
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(Attrs(ctx)...)
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
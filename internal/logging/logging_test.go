@@ -0,0 +1,116 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
+	"github.com/Drinnn/eligible-offers-api/internal/middlewares"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+func setupLoggedRouter(t *testing.T, handler middlewares.HandlerFunc) (http.Handler, *bytes.Buffer) {
+	output := new(bytes.Buffer)
+	previous := slog.Default()
+	slog.SetDefault(logging.New(output, config.LogConfig{Level: "info", Format: "json"}))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	router := chi.NewRouter()
+	router.Use(middlewares.RequestID)
+	router.Use(middlewares.RequestLogger(router))
+	router.Post("/users/{user_id}/offers/{offer_id}/redeem", middlewares.ErrorHandler(handler))
+	return router, output
+}
+
+func decodeLogLines(t *testing.T, output *bytes.Buffer) []map[string]any {
+	lines := make([]map[string]any, 0)
+	decoder := json.NewDecoder(output)
+	for {
+		var line map[string]any
+		if err := decoder.Decode(&line); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to decode log line: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestLogging_ServiceErrorLogsCauseWithRequestContext(t *testing.T) {
+	// Given: A route whose handler fails with a wrapped repository error
+	cause := errors.New("connection refused")
+	router, output := setupLoggedRouter(t, func(w http.ResponseWriter, r *http.Request) error {
+		return customErrors.NewServiceError("failed to redeem offer", cause)
+	})
+
+	// When: A request arrives with a request ID
+	request := httptest.NewRequest(http.MethodPost, "/users/user-1/offers/offer-1/redeem", nil)
+	request.Header.Set(middlewares.RequestIDHeader, "req-123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	// Then: The request ID is echoed back
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", recorder.Code)
+	}
+	if got := recorder.Header().Get(middlewares.RequestIDHeader); got != "req-123" {
+		t.Errorf("expected request ID to be echoed, got %q", got)
+	}
+
+	// And: Both the error and the request lines carry the request context
+	lines := decodeLogLines(t, output)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %v", len(lines), lines)
+	}
+	for _, line := range lines {
+		if line["request_id"] != "req-123" || line["route"] != "/users/{user_id}/offers/{offer_id}/redeem" || line["user_id"] != "user-1" || line["offer_id"] != "offer-1" {
+			t.Errorf("expected request context in log line, got %v", line)
+		}
+	}
+
+	// And: The error line logs the cause rather than only the message
+	if lines[0]["level"] != "ERROR" || lines[0]["error"] != "failed to redeem offer" || lines[0]["cause"] != "connection refused" {
+		t.Errorf("expected the service error and its cause to be logged, got %v", lines[0])
+	}
+	if lines[1]["msg"] != "request completed" || lines[1]["status"] != float64(http.StatusInternalServerError) {
+		t.Errorf("expected a request line with status 500, got %v", lines[1])
+	}
+}
+
+func TestLogging_GeneratesRequestIDWhenMissingOrInvalid(t *testing.T) {
+	// Given: A route whose handler adds no attributes of its own
+	router, output := setupLoggedRouter(t, func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+
+	for _, header := range []string{"", "has spaces\nand newlines"} {
+		output.Reset()
+
+		// When: A request arrives without a usable request ID
+		request := httptest.NewRequest(http.MethodPost, "/users/user-1/offers/offer-1/redeem", nil)
+		request.Header.Set(middlewares.RequestIDHeader, header)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		// Then: A new UUID is generated, echoed and logged
+		requestID := recorder.Header().Get(middlewares.RequestIDHeader)
+		if _, err := uuid.Parse(requestID); err != nil {
+			t.Fatalf("expected a generated UUID for header %q, got %q", header, requestID)
+		}
+		lines := decodeLogLines(t, output)
+		if len(lines) != 1 || lines[0]["request_id"] != requestID {
+			t.Errorf("expected the generated request ID in the log line, got %v", lines)
+		}
+	}
+}
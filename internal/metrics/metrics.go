@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// maxOfferLabels bounds the offer_id label of the eligibility metrics: offers first seen once
+// that many have been labelled are reported as otherOfferLabel.
+const (
+	maxOfferLabels  = 200
+	otherOfferLabel = "other"
+)
+
+// Metrics collects the service's Prometheus metrics on its own registry. It implements the
+// observer interfaces of the request middleware, the repository decorators and the use case
+// decorators, so none of them depend on Prometheus.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	transactions       *prometheus.CounterVec
+	offersEvaluated    prometheus.Histogram
+	offerEvaluations   *prometheus.CounterVec
+	repositoryDuration *prometheus.HistogramVec
+
+	mu          sync.Mutex
+	offerLabels map[string]bool
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method and route pattern.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transactions_total",
+			Help: "Transactions received for ingestion by result: ingested, duplicated or rejected.",
+		}, []string{"result"}),
+		offersEvaluated: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "eligibility_offers_evaluated",
+			Help:    "Offers live during each eligibility computation; cached responses are not counted.",
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+		}),
+		offerEvaluations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "eligibility_offer_evaluations_total",
+			Help: "Live offers per eligibility computation by offer and result: eligible, in_progress or not_eligible.",
+		}, []string{"offer_id", "result"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_operation_duration_seconds",
+			Help:    "Repository call latency by repository, operation and outcome.",
+			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"repository", "operation", "outcome"}),
+		offerLabels: make(map[string]bool),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.transactions,
+		m.offersEvaluated,
+		m.offerEvaluations,
+		m.repositoryDuration,
+	)
+	return m
+}
+
+// CacheStats is a snapshot of a cache's counters, read on every scrape.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// RegisterEligibilityCache exports the eligibility cache's counters, which the cache keeps itself.
+func (m *Metrics) RegisterEligibilityCache(stats func() CacheStats) {
+	m.registry.MustRegister(&cacheCollector{
+		stats:     stats,
+		hits:      prometheus.NewDesc("eligibility_cache_hits_total", "Eligibility requests answered from the cache.", nil, nil),
+		misses:    prometheus.NewDesc("eligibility_cache_misses_total", "Eligibility requests computed because no fresh entry was cached.", nil, nil),
+		evictions: prometheus.NewDesc("eligibility_cache_evictions_total", "Entries evicted to make room for new ones.", nil, nil),
+		size:      prometheus.NewDesc("eligibility_cache_entries", "Entries currently cached.", nil, nil),
+	})
+}
+
+type cacheCollector struct {
+	stats                         func() CacheStats
+	hits, misses, evictions, size *prometheus.Desc
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.size
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveTransactions(ingested, duplicated, rejected int) {
+	m.transactions.WithLabelValues("ingested").Add(float64(ingested))
+	m.transactions.WithLabelValues("duplicated").Add(float64(duplicated))
+	m.transactions.WithLabelValues("rejected").Add(float64(rejected))
+}
+
+func (m *Metrics) ObserveEligibility(results map[string]string) {
+	m.offersEvaluated.Observe(float64(len(results)))
+	for offerID, result := range results {
+		m.offerEvaluations.WithLabelValues(m.offerLabel(offerID), result).Inc()
+	}
+}
+
+// offerLabel returns the offer's ID while fewer than maxOfferLabels offers have one.
+func (m *Metrics) offerLabel(offerID string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.offerLabels[offerID] {
+		if len(m.offerLabels) >= maxOfferLabels {
+			return otherOfferLabel
+		}
+		m.offerLabels[offerID] = true
+	}
+	return offerID
+}
+
+func (m *Metrics) StartOperation(ctx context.Context, repository, operation string) (context.Context, func(err error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+		}
+		m.repositoryDuration.WithLabelValues(repository, operation, outcome).Observe(time.Since(start).Seconds())
+	}
+}
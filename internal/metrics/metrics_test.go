@@ -0,0 +1,42 @@
+package metrics_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Drinnn/eligible-offers-api/internal/metrics"
+)
+
+func TestMetrics_OfferLabelsAreCapped(t *testing.T) {
+	// Given: Eligibility results for more offers than get their own label
+	m := metrics.New()
+	results := make(map[string]string)
+	for i := range 200 {
+		results[fmt.Sprintf("offer-%03d", i)] = "eligible"
+	}
+	m.ObserveEligibility(results)
+
+	// When: Offers never seen before and an already labelled one are observed again
+	m.ObserveEligibility(map[string]string{"offer-new-1": "eligible", "offer-new-2": "not_eligible", "offer-000": "not_eligible"})
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	// Then: The new offers are reported under the shared label and the labelled one keeps its own
+	for _, line := range []string{
+		`eligibility_offer_evaluations_total{offer_id="other",result="eligible"} 1`,
+		`eligibility_offer_evaluations_total{offer_id="other",result="not_eligible"} 1`,
+		`eligibility_offer_evaluations_total{offer_id="offer-000",result="not_eligible"} 1`,
+		`eligibility_offers_evaluated_sum 203`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q", line)
+		}
+	}
+	if strings.Contains(body, `offer_id="offer-new-1"`) {
+		t.Error("Expected offers past the cap not to get their own label")
+	}
+}
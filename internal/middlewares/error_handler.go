@@ -1,35 +1,75 @@
 package middlewares
 
 import (
-	"encoding/json"
-	"log"
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/helpers"
 )
 
 type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
 
+// statusClientClosedRequest is logged for requests the client abandoned; nobody reads the response.
+const statusClientClosedRequest = 499
+
 func ErrorHandler(h HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if err := h(w, r); err != nil {
-			switch e := err.(type) {
-			case *httpErrors.HttpError:
-				w.WriteHeader(e.StatusCode)
-				json.NewEncoder(w).Encode(e)
-			case *httpErrors.ServiceError:
-				log.Printf("Service error: %v", e)
-				w.WriteHeader(http.StatusInternalServerError)
-				json.NewEncoder(w).Encode(map[string]any{
-					"message": e.Error(),
-				})
-			default:
-				log.Printf("Unknown error: %v", err)
-				w.WriteHeader(http.StatusInternalServerError)
-				json.NewEncoder(w).Encode(map[string]any{
-					"message": "Internal server error",
-				})
+		err := h(w, r)
+		if err == nil {
+			return
+		}
+		httpError := toHttpError(err, r.Header.Get("Accept-Language"))
+		isServerError := httpError.StatusCode >= http.StatusInternalServerError
+
+		// Failures after the route's deadline passed are reported as timeouts, since
+		// repository errors caused by the deadline are wrapped by the use cases.
+		if errors.Is(err, context.DeadlineExceeded) || (isServerError && errors.Is(r.Context().Err(), context.DeadlineExceeded)) {
+			httpError = httpErrors.NewGatewayTimeoutError("Request timed out").(*httpErrors.HttpError)
+		} else if errors.Is(err, context.Canceled) || (isServerError && errors.Is(r.Context().Err(), context.Canceled)) {
+			w.WriteHeader(statusClientClosedRequest)
+			return
+		} else if isServerError {
+			var serviceError *httpErrors.ServiceError
+			if errors.As(err, &serviceError) {
+				slog.ErrorContext(r.Context(), "service error", slog.String("error", serviceError.Message), slog.Any("cause", serviceError.Cause))
+			} else {
+				slog.ErrorContext(r.Context(), "unknown error", slog.Any("error", err))
 			}
 		}
+
+		WriteProblem(w, r, httpError)
+	}
+}
+
+// toHttpError maps an error to its response. Only the messages of domain and service errors
+// reach the client; causes and unknown errors stay in the logs. Translatable field errors are
+// given in the first supported language of acceptLanguage.
+func toHttpError(err error, acceptLanguage string) *httpErrors.HttpError {
+	var httpError *httpErrors.HttpError
+	var validationError *httpErrors.ValidationError
+	var notFoundError *httpErrors.NotFoundError
+	var conflictError *httpErrors.ConflictError
+	var serviceError *httpErrors.ServiceError
+
+	switch {
+	case errors.As(err, &httpError):
+		return httpError
+	case errors.As(err, &validationError):
+		fieldErrors := validationError.Errors
+		if validationError.Messages != nil {
+			fieldErrors = helpers.TranslateMessages(validationError.Messages, acceptLanguage)
+		}
+		return &httpErrors.HttpError{StatusCode: http.StatusBadRequest, Message: validationError.Message, Errors: fieldErrors}
+	case errors.As(err, &notFoundError):
+		return &httpErrors.HttpError{StatusCode: http.StatusNotFound, Message: notFoundError.Message}
+	case errors.As(err, &conflictError):
+		return &httpErrors.HttpError{StatusCode: http.StatusConflict, Message: conflictError.Message}
+	case errors.As(err, &serviceError):
+		return &httpErrors.HttpError{StatusCode: http.StatusInternalServerError, Message: serviceError.Message}
+	default:
+		return &httpErrors.HttpError{StatusCode: http.StatusInternalServerError, Message: "Internal server error"}
 	}
 }
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net/http"
+
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+)
+
+// MaxBodySize rejects requests whose declared body is larger than limit with a 413, and
+// caps the body of the rest so a missing or wrong Content-Length cannot exceed it. Handlers
+// report bodies cut off by the cap with a 413 as well.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				WriteProblem(w, r, httpErrors.NewPayloadTooLargeError("Request body too large").(*httpErrors.HttpError))
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestObserver records the outcome and latency of HTTP requests.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// unmatchedRoute labels requests no route matched, so arbitrary paths do not create series.
+const unmatchedRoute = "unmatched"
+
+// Metrics reports every request under its chi route pattern, e.g. "/users/{user_id}", which
+// is only known once the router has handled the request.
+func Metrics(observer RequestObserver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			observer.ObserveRequest(r.Method, route, status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	httpErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/go-chi/chi"
+)
+
+// WriteProblem writes err as an application/problem+json response whose instance is the
+// request ID.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err *httpErrors.HttpError) {
+	w.Header().Set("Content-Type", httpErrors.ProblemContentType)
+	w.WriteHeader(err.StatusCode)
+	json.NewEncoder(w).Encode(httpErrors.NewProblem(err, GetRequestID(r.Context())))
+}
+
+// NotFound replaces chi's plain text 404 for paths no route matches.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, toHttpError(httpErrors.NewNotFoundError("No route matches "+r.URL.Path), ""))
+}
+
+var routeMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// MethodNotAllowed replaces chi's plain text 405 for paths that router matches with other
+// methods, which it lists in the Allow header.
+func MethodNotAllowed(router chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		allowed := slices.DeleteFunc(slices.Clone(routeMethods), func(method string) bool {
+			return !router.Match(chi.NewRouteContext(), method, r.URL.Path)
+		})
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		WriteProblem(w, r, httpErrors.NewMethodNotAllowedError("Method "+r.Method+" is not allowed on "+r.URL.Path).(*httpErrors.HttpError))
+	}
+}
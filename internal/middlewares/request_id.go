@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds caller-provided IDs, which end up in every log line of the request.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID tags the request with the caller's X-Request-ID, or a new UUID when it is missing
+// or malformed, echoes it in the response and attaches it to the request's log records.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
+		ctx = logging.NewContext(ctx, slog.String("request_id", requestID))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetRequestID returns the ID RequestID assigned to the request, or "" outside of it.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// isValidRequestID accepts printable ASCII without spaces, so IDs cannot forge log fields.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/logging"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// loggedURLParams are the route parameters every log line of a request carries when present.
+var loggedURLParams = []string{"user_id", "offer_id"}
+
+// RequestLogger attaches the route the request matches in router, and its user_id and offer_id
+// parameters, to the request's log records, then logs one line per request once it completes.
+// Handlers can add identifiers only found in the body with logging.AddAttrs.
+func RequestLogger(router chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			attrs := []slog.Attr{slog.String("route", unmatchedRoute)}
+			if rctx := chi.NewRouteContext(); router.Match(rctx, r.Method, r.URL.Path) {
+				attrs[0] = slog.String("route", rctx.RoutePattern())
+				for _, param := range loggedURLParams {
+					if value := rctx.URLParam(param); value != "" {
+						attrs = append(attrs, slog.String(param, value))
+					}
+				}
+			}
+			ctx := logging.NewContext(r.Context(), attrs...)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			slog.LogAttrs(ctx, slog.LevelInfo, "request completed",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+			)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+// RouteTimeouts configures the deadline of each request. Routes are keyed by method and
+// chi pattern, e.g. "POST /transactions"; other routes use Default. Zero disables the deadline.
+type RouteTimeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+func (t RouteTimeouts) For(method, pattern string) time.Duration {
+	if timeout, exists := t.Routes[method+" "+pattern]; exists {
+		return timeout
+	}
+	return t.Default
+}
+
+// Timeout sets a deadline on the request context for the route the request matches in
+// router. Handlers stop when the use case observes the deadline, and ErrorHandler turns
+// the resulting error into a 504.
+func Timeout(router chi.Routes, timeouts RouteTimeouts) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !router.Match(rctx, r.Method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timeout := timeouts.For(r.Method, rctx.RoutePattern())
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type BudgetRepository interface {
+	Pinger
+	// Consume atomically records one redemption of amountCents against the offer,
+	// failing with ErrBudgetExhausted if it would exceed maxRedemptions or
+	// budgetCents. Zero limits mean unlimited.
+	Consume(ctx context.Context, offerID string, amountCents int64, maxRedemptions int, budgetCents int64, at time.Time) (*entities.BudgetUsage, error)
+	// Release gives back a redemption previously taken with Consume.
+	Release(ctx context.Context, offerID string, amountCents int64) error
+	// GetByOfferID returns zero usage for offers that were never redeemed.
+	GetByOfferID(ctx context.Context, offerID string) (*entities.BudgetUsage, error)
+}
+
+type InMemoryBudgetRepository struct {
+	mu     sync.RWMutex
+	usages map[string]*entities.BudgetUsage
+}
+
+func NewInMemoryBudgetRepository() *InMemoryBudgetRepository {
+	return &InMemoryBudgetRepository{
+		usages: make(map[string]*entities.BudgetUsage),
+	}
+}
+
+func (r *InMemoryBudgetRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryBudgetRepository) Consume(ctx context.Context, offerID string, amountCents int64, maxRedemptions int, budgetCents int64, at time.Time) (*entities.BudgetUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage, exists := r.usages[offerID]
+	if !exists {
+		usage = &entities.BudgetUsage{OfferID: offerID}
+	}
+
+	if maxRedemptions > 0 && usage.Redemptions+1 > maxRedemptions {
+		return nil, ErrBudgetExhausted
+	}
+	if budgetCents > 0 && usage.SpentCents+amountCents > budgetCents {
+		return nil, ErrBudgetExhausted
+	}
+
+	updated := *usage
+	updated.Redemptions++
+	updated.SpentCents += amountCents
+	if updated.FirstRedemptionAt.IsZero() || at.Before(updated.FirstRedemptionAt) {
+		updated.FirstRedemptionAt = at
+	}
+	if at.After(updated.LastRedemptionAt) {
+		updated.LastRedemptionAt = at
+	}
+	r.usages[offerID] = &updated
+
+	snapshot := updated
+	return &snapshot, nil
+}
+
+func (r *InMemoryBudgetRepository) Release(ctx context.Context, offerID string, amountCents int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage, exists := r.usages[offerID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	updated := *usage
+	updated.Redemptions--
+	updated.SpentCents -= amountCents
+	r.usages[offerID] = &updated
+
+	return nil
+}
+
+func (r *InMemoryBudgetRepository) GetByOfferID(ctx context.Context, offerID string) (*entities.BudgetUsage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usage, exists := r.usages[offerID]
+	if !exists {
+		return &entities.BudgetUsage{OfferID: offerID}, nil
+	}
+
+	snapshot := *usage
+	return &snapshot, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// EligibilitySnapshotRepository remembers which offers each user qualified for at their last evaluation.
+type EligibilitySnapshotRepository interface {
+	Pinger
+	// Swap stores the user's current eligible offer IDs and returns the previous ones.
+	Swap(ctx context.Context, userID string, offerIDs []string) ([]string, error)
+}
+
+type InMemoryEligibilitySnapshotRepository struct {
+	mu        sync.Mutex
+	snapshots map[string][]string
+}
+
+func NewInMemoryEligibilitySnapshotRepository() *InMemoryEligibilitySnapshotRepository {
+	return &InMemoryEligibilitySnapshotRepository{
+		snapshots: make(map[string][]string),
+	}
+}
+
+func (r *InMemoryEligibilitySnapshotRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryEligibilitySnapshotRepository) Swap(ctx context.Context, userID string, offerIDs []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.snapshots[userID]
+	r.snapshots[userID] = offerIDs
+
+	return previous, nil
+}
+
+func (r *InMemoryEligibilitySnapshotRepository) get(userID string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.snapshots[userID]
+}
+
+// snapshotRecord is one line of the snapshot log: a user's eligible offer IDs after an evaluation.
+type snapshotRecord struct {
+	UserID   string   `json:"user_id"`
+	OfferIDs []string `json:"offer_ids"`
+}
+
+// FileEligibilitySnapshotRepository keeps snapshots in memory and writes every change to an
+// append-only JSON lines log, which is replayed on startup so users are not reported as gaining
+// all their offers again after a restart.
+type FileEligibilitySnapshotRepository struct {
+	*InMemoryEligibilitySnapshotRepository
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileEligibilitySnapshotRepository(path string) (*FileEligibilitySnapshotRepository, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot log: %w", err)
+	}
+
+	r := &FileEligibilitySnapshotRepository{
+		InMemoryEligibilitySnapshotRepository: NewInMemoryEligibilitySnapshotRepository(),
+		file:                                  file,
+	}
+	if err := r.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *FileEligibilitySnapshotRepository) replay() error {
+	scanner := bufio.NewScanner(r.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record snapshotRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("parse snapshot log line %d: %w", line, err)
+		}
+		r.InMemoryEligibilitySnapshotRepository.Swap(context.Background(), record.UserID, record.OfferIDs)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read snapshot log: %w", err)
+	}
+	return nil
+}
+
+func (r *FileEligibilitySnapshotRepository) Swap(ctx context.Context, userID string, offerIDs []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Most re-evaluations change nothing, so only changed snapshots are logged.
+	if previous := r.get(userID); slices.Equal(previous, offerIDs) {
+		return previous, nil
+	}
+
+	if err := r.write(snapshotRecord{UserID: userID, OfferIDs: offerIDs}); err != nil {
+		return nil, err
+	}
+	return r.InMemoryEligibilitySnapshotRepository.Swap(ctx, userID, offerIDs)
+}
+
+// Ping fails once the log is closed or can no longer be read.
+func (r *FileEligibilitySnapshotRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.file.Stat(); err != nil {
+		return fmt.Errorf("stat snapshot log: %w", err)
+	}
+	return nil
+}
+
+// write appends the record and syncs it to disk before the in-memory state is updated.
+func (r *FileEligibilitySnapshotRepository) write(record snapshotRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode snapshot record: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write snapshot log: %w", err)
+	}
+	if err := r.file.Sync(); err != nil {
+		return fmt.Errorf("sync snapshot log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the log to disk and closes it.
+func (r *FileEligibilitySnapshotRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Sync(); err != nil {
+		r.file.Close()
+		return fmt.Errorf("sync snapshot log: %w", err)
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyRedeemed = errors.New("already redeemed")
+	ErrBudgetExhausted = errors.New("budget exhausted")
+)
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type ExperimentRepository interface {
+	Pinger
+	Upsert(ctx context.Context, experiment *entities.Experiment) error
+	GetByID(ctx context.Context, id string) (*entities.Experiment, error)
+	GetByOfferID(ctx context.Context, offerID string) (*entities.Experiment, error)
+	// RecordExposure keeps the latest exposure per experiment and user.
+	RecordExposure(ctx context.Context, exposure *entities.ExperimentExposure) error
+	GetExposures(ctx context.Context, experimentID string) ([]*entities.ExperimentExposure, error)
+}
+
+type InMemoryExperimentRepository struct {
+	mu          sync.RWMutex
+	experiments map[string]*entities.Experiment
+	exposures   map[string]map[string]*entities.ExperimentExposure // experiment ID -> user ID -> exposure
+}
+
+func NewInMemoryExperimentRepository() *InMemoryExperimentRepository {
+	return &InMemoryExperimentRepository{
+		experiments: make(map[string]*entities.Experiment),
+		exposures:   make(map[string]map[string]*entities.ExperimentExposure),
+	}
+}
+
+func (r *InMemoryExperimentRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryExperimentRepository) Upsert(ctx context.Context, experiment *entities.Experiment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.experiments[experiment.ID] = experiment
+
+	return nil
+}
+
+func (r *InMemoryExperimentRepository) GetByID(ctx context.Context, id string) (*entities.Experiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	experiment, exists := r.experiments[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return experiment, nil
+}
+
+func (r *InMemoryExperimentRepository) GetByOfferID(ctx context.Context, offerID string) (*entities.Experiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, experiment := range r.experiments {
+		if experiment.OfferID == offerID {
+			return experiment, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *InMemoryExperimentRepository) RecordExposure(ctx context.Context, exposure *entities.ExperimentExposure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exposures, exists := r.exposures[exposure.ExperimentID]
+	if !exists {
+		exposures = make(map[string]*entities.ExperimentExposure)
+		r.exposures[exposure.ExperimentID] = exposures
+	}
+	exposures[exposure.UserID] = exposure
+
+	return nil
+}
+
+func (r *InMemoryExperimentRepository) GetExposures(ctx context.Context, experimentID string) ([]*entities.ExperimentExposure, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exposures := make([]*entities.ExperimentExposure, 0, len(r.exposures[experimentID]))
+	for _, exposure := range r.exposures[experimentID] {
+		exposures = append(exposures, exposure)
+	}
+	return exposures, nil
+}
@@ -0,0 +1,697 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+// OperationObserver is told when a repository call starts, and returns the context to make the
+// call with and a function to call with its result.
+type OperationObserver interface {
+	StartOperation(ctx context.Context, repository, operation string) (context.Context, func(err error))
+}
+
+// JoinObservers returns an observer notifying each non-nil observer in order, or nil if there
+// are none.
+func JoinObservers(observers ...OperationObserver) OperationObserver {
+	joined := make(joinedObservers, 0, len(observers))
+	for _, observer := range observers {
+		if observer != nil {
+			joined = append(joined, observer)
+		}
+	}
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return joined
+}
+
+type joinedObservers []OperationObserver
+
+func (o joinedObservers) StartOperation(ctx context.Context, repository, operation string) (context.Context, func(err error)) {
+	finishers := make([]func(err error), len(o))
+	for i, observer := range o {
+		ctx, finishers[i] = observer.StartOperation(ctx, repository, operation)
+	}
+	return ctx, func(err error) {
+		for i := len(finishers) - 1; i >= 0; i-- {
+			finishers[i](err)
+		}
+	}
+}
+
+// instrumented reports the calls of a repository decorator under the repository's name.
+// The Instrument* constructors wrap a repository so every call, including Ping, is reported to
+// the observer; they return the repository unchanged when the observer is nil.
+type instrumented struct {
+	repository string
+	observer   OperationObserver
+}
+
+func (i instrumented) start(ctx context.Context, operation string) (context.Context, func(err error)) {
+	return i.observer.StartOperation(ctx, i.repository, operation)
+}
+
+type instrumentedBudgetRepository struct {
+	instrumented
+	next BudgetRepository
+}
+
+func InstrumentBudgetRepository(next BudgetRepository, observer OperationObserver) BudgetRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedBudgetRepository{instrumented: instrumented{repository: "budgets", observer: observer}, next: next}
+}
+
+func (r *instrumentedBudgetRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedBudgetRepository) Consume(ctx context.Context, offerID string, amountCents int64, maxRedemptions int, budgetCents int64, at time.Time) (*entities.BudgetUsage, error) {
+	ctx, finish := r.start(ctx, "Consume")
+	result, err := r.next.Consume(ctx, offerID, amountCents, maxRedemptions, budgetCents, at)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedBudgetRepository) Release(ctx context.Context, offerID string, amountCents int64) error {
+	ctx, finish := r.start(ctx, "Release")
+	err := r.next.Release(ctx, offerID, amountCents)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedBudgetRepository) GetByOfferID(ctx context.Context, offerID string) (*entities.BudgetUsage, error) {
+	ctx, finish := r.start(ctx, "GetByOfferID")
+	result, err := r.next.GetByOfferID(ctx, offerID)
+	finish(err)
+	return result, err
+}
+
+type instrumentedEligibilitySnapshotRepository struct {
+	instrumented
+	next EligibilitySnapshotRepository
+}
+
+func InstrumentEligibilitySnapshotRepository(next EligibilitySnapshotRepository, observer OperationObserver) EligibilitySnapshotRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedEligibilitySnapshotRepository{instrumented: instrumented{repository: "eligibility_snapshots", observer: observer}, next: next}
+}
+
+func (r *instrumentedEligibilitySnapshotRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedEligibilitySnapshotRepository) Swap(ctx context.Context, userID string, offerIDs []string) ([]string, error) {
+	ctx, finish := r.start(ctx, "Swap")
+	result, err := r.next.Swap(ctx, userID, offerIDs)
+	finish(err)
+	return result, err
+}
+
+type instrumentedExperimentRepository struct {
+	instrumented
+	next ExperimentRepository
+}
+
+func InstrumentExperimentRepository(next ExperimentRepository, observer OperationObserver) ExperimentRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedExperimentRepository{instrumented: instrumented{repository: "experiments", observer: observer}, next: next}
+}
+
+func (r *instrumentedExperimentRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedExperimentRepository) Upsert(ctx context.Context, experiment *entities.Experiment) error {
+	ctx, finish := r.start(ctx, "Upsert")
+	err := r.next.Upsert(ctx, experiment)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedExperimentRepository) GetByID(ctx context.Context, id string) (*entities.Experiment, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedExperimentRepository) GetByOfferID(ctx context.Context, offerID string) (*entities.Experiment, error) {
+	ctx, finish := r.start(ctx, "GetByOfferID")
+	result, err := r.next.GetByOfferID(ctx, offerID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedExperimentRepository) RecordExposure(ctx context.Context, exposure *entities.ExperimentExposure) error {
+	ctx, finish := r.start(ctx, "RecordExposure")
+	err := r.next.RecordExposure(ctx, exposure)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedExperimentRepository) GetExposures(ctx context.Context, experimentID string) ([]*entities.ExperimentExposure, error) {
+	ctx, finish := r.start(ctx, "GetExposures")
+	result, err := r.next.GetExposures(ctx, experimentID)
+	finish(err)
+	return result, err
+}
+
+type instrumentedMCCRepository struct {
+	instrumented
+	next MCCRepository
+}
+
+func InstrumentMCCRepository(next MCCRepository, observer OperationObserver) MCCRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedMCCRepository{instrumented: instrumented{repository: "mccs", observer: observer}, next: next}
+}
+
+func (r *instrumentedMCCRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedMCCRepository) GetAll(ctx context.Context) ([]*entities.MCC, error) {
+	ctx, finish := r.start(ctx, "GetAll")
+	result, err := r.next.GetAll(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedMCCRepository) GetByCode(ctx context.Context, code string) (*entities.MCC, error) {
+	ctx, finish := r.start(ctx, "GetByCode")
+	result, err := r.next.GetByCode(ctx, code)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedMCCRepository) GetByCategory(ctx context.Context, category string) ([]*entities.MCC, error) {
+	ctx, finish := r.start(ctx, "GetByCategory")
+	result, err := r.next.GetByCategory(ctx, category)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedMCCRepository) GetCategories(ctx context.Context) ([]*entities.MCCCategory, error) {
+	ctx, finish := r.start(ctx, "GetCategories")
+	result, err := r.next.GetCategories(ctx)
+	finish(err)
+	return result, err
+}
+
+type instrumentedOfferRepository struct {
+	instrumented
+	next OfferRepository
+}
+
+func InstrumentOfferRepository(next OfferRepository, observer OperationObserver) OfferRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedOfferRepository{instrumented: instrumented{repository: "offers", observer: observer}, next: next}
+}
+
+func (r *instrumentedOfferRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOfferRepository) Upsert(ctx context.Context, offer *entities.Offer) error {
+	ctx, finish := r.start(ctx, "Upsert")
+	err := r.next.Upsert(ctx, offer)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOfferRepository) GetAll(ctx context.Context) ([]*entities.Offer, error) {
+	ctx, finish := r.start(ctx, "GetAll")
+	result, err := r.next.GetAll(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOfferRepository) GetByID(ctx context.Context, id string) (*entities.Offer, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOfferRepository) GetActiveAt(ctx context.Context, now time.Time) ([]*entities.Offer, error) {
+	ctx, finish := r.start(ctx, "GetActiveAt")
+	result, err := r.next.GetActiveAt(ctx, now)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOfferRepository) GetByMerchantsOrMCCs(ctx context.Context, merchantIDs []string, mccs []string) ([]*entities.Offer, error) {
+	ctx, finish := r.start(ctx, "GetByMerchantsOrMCCs")
+	result, err := r.next.GetByMerchantsOrMCCs(ctx, merchantIDs, mccs)
+	finish(err)
+	return result, err
+}
+
+type instrumentedOfferMatchRepository struct {
+	instrumented
+	next OfferMatchRepository
+}
+
+func InstrumentOfferMatchRepository(next OfferMatchRepository, observer OperationObserver) OfferMatchRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedOfferMatchRepository{instrumented: instrumented{repository: "offer_matches", observer: observer}, next: next}
+}
+
+func (r *instrumentedOfferMatchRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOfferMatchRepository) GetSummaries(ctx context.Context, userID string, offers []*entities.Offer, now time.Time) (map[string]OfferMatchSummary, error) {
+	ctx, finish := r.start(ctx, "GetSummaries")
+	result, err := r.next.GetSummaries(ctx, userID, offers, now)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOfferMatchRepository) Add(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error {
+	ctx, finish := r.start(ctx, "Add")
+	err := r.next.Add(ctx, offer, transactions)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOfferMatchRepository) ReplaceOffer(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error {
+	ctx, finish := r.start(ctx, "ReplaceOffer")
+	err := r.next.ReplaceOffer(ctx, offer, transactions)
+	finish(err)
+	return err
+}
+
+type instrumentedOutboxRepository struct {
+	instrumented
+	next OutboxRepository
+}
+
+func InstrumentOutboxRepository(next OutboxRepository, observer OperationObserver) OutboxRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedOutboxRepository{instrumented: instrumented{repository: "outbox", observer: observer}, next: next}
+}
+
+func (r *instrumentedOutboxRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOutboxRepository) Append(ctx context.Context, events []*entities.OfferEvent) error {
+	ctx, finish := r.start(ctx, "Append")
+	err := r.next.Append(ctx, events)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedOutboxRepository) GetPending(ctx context.Context, limit int) ([]*entities.OfferEvent, error) {
+	ctx, finish := r.start(ctx, "GetPending")
+	result, err := r.next.GetPending(ctx, limit)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	ctx, finish := r.start(ctx, "CountPending")
+	result, err := r.next.CountPending(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedOutboxRepository) MarkDispatched(ctx context.Context, eventIDs []string, at time.Time) error {
+	ctx, finish := r.start(ctx, "MarkDispatched")
+	err := r.next.MarkDispatched(ctx, eventIDs, at)
+	finish(err)
+	return err
+}
+
+type instrumentedRedemptionRepository struct {
+	instrumented
+	next RedemptionRepository
+}
+
+func InstrumentRedemptionRepository(next RedemptionRepository, observer OperationObserver) RedemptionRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedRedemptionRepository{instrumented: instrumented{repository: "redemptions", observer: observer}, next: next}
+}
+
+func (r *instrumentedRedemptionRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedRedemptionRepository) Activate(ctx context.Context, activation *entities.Activation) (*entities.Activation, error) {
+	ctx, finish := r.start(ctx, "Activate")
+	result, err := r.next.Activate(ctx, activation)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedRedemptionRepository) GetActivation(ctx context.Context, userID, offerID string) (*entities.Activation, error) {
+	ctx, finish := r.start(ctx, "GetActivation")
+	result, err := r.next.GetActivation(ctx, userID, offerID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedRedemptionRepository) GetActivationsByUserID(ctx context.Context, userID string) ([]*entities.Activation, error) {
+	ctx, finish := r.start(ctx, "GetActivationsByUserID")
+	result, err := r.next.GetActivationsByUserID(ctx, userID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedRedemptionRepository) InsertRedemption(ctx context.Context, redemption *entities.Redemption, singleUse bool) error {
+	ctx, finish := r.start(ctx, "InsertRedemption")
+	err := r.next.InsertRedemption(ctx, redemption, singleUse)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedRedemptionRepository) GetRedemptionsByUserID(ctx context.Context, userID string) ([]*entities.Redemption, error) {
+	ctx, finish := r.start(ctx, "GetRedemptionsByUserID")
+	result, err := r.next.GetRedemptionsByUserID(ctx, userID)
+	finish(err)
+	return result, err
+}
+
+type instrumentedSegmentRepository struct {
+	instrumented
+	next SegmentRepository
+}
+
+func InstrumentSegmentRepository(next SegmentRepository, observer OperationObserver) SegmentRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedSegmentRepository{instrumented: instrumented{repository: "segments", observer: observer}, next: next}
+}
+
+func (r *instrumentedSegmentRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedSegmentRepository) Upsert(ctx context.Context, segment *entities.Segment) error {
+	ctx, finish := r.start(ctx, "Upsert")
+	err := r.next.Upsert(ctx, segment)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedSegmentRepository) GetByID(ctx context.Context, id string) (*entities.Segment, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedSegmentRepository) GetAll(ctx context.Context) ([]*entities.Segment, error) {
+	ctx, finish := r.start(ctx, "GetAll")
+	result, err := r.next.GetAll(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedSegmentRepository) Delete(ctx context.Context, id string) error {
+	ctx, finish := r.start(ctx, "Delete")
+	err := r.next.Delete(ctx, id)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedSegmentRepository) AddMembers(ctx context.Context, segmentID string, userIDs []string) (int, error) {
+	ctx, finish := r.start(ctx, "AddMembers")
+	result, err := r.next.AddMembers(ctx, segmentID, userIDs)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedSegmentRepository) ReplaceMembers(ctx context.Context, segmentID string, userIDs []string) error {
+	ctx, finish := r.start(ctx, "ReplaceMembers")
+	err := r.next.ReplaceMembers(ctx, segmentID, userIDs)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedSegmentRepository) CountMembers(ctx context.Context, segmentID string) (int, error) {
+	ctx, finish := r.start(ctx, "CountMembers")
+	result, err := r.next.CountMembers(ctx, segmentID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedSegmentRepository) GetSegmentIDsByUserID(ctx context.Context, userID string) ([]string, error) {
+	ctx, finish := r.start(ctx, "GetSegmentIDsByUserID")
+	result, err := r.next.GetSegmentIDsByUserID(ctx, userID)
+	finish(err)
+	return result, err
+}
+
+type instrumentedTransactionRepository struct {
+	instrumented
+	next TransactionRepository
+}
+
+func InstrumentTransactionRepository(next TransactionRepository, observer OperationObserver) TransactionRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedTransactionRepository{instrumented: instrumented{repository: "transactions", observer: observer}, next: next}
+}
+
+func (r *instrumentedTransactionRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedTransactionRepository) Insert(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error) {
+	ctx, finish := r.start(ctx, "Insert")
+	result, err := r.next.Insert(ctx, transactions)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedTransactionRepository) GetByUserID(ctx context.Context, userID string) ([]*entities.Transaction, error) {
+	ctx, finish := r.start(ctx, "GetByUserID")
+	result, err := r.next.GetByUserID(ctx, userID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedTransactionRepository) GetUserIDs(ctx context.Context) ([]string, error) {
+	ctx, finish := r.start(ctx, "GetUserIDs")
+	result, err := r.next.GetUserIDs(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedTransactionRepository) GetAll(ctx context.Context) ([]*entities.Transaction, error) {
+	ctx, finish := r.start(ctx, "GetAll")
+	result, err := r.next.GetAll(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedTransactionRepository) HasMerchant(ctx context.Context, merchantID string) (bool, error) {
+	ctx, finish := r.start(ctx, "HasMerchant")
+	result, err := r.next.HasMerchant(ctx, merchantID)
+	finish(err)
+	return result, err
+}
+
+type instrumentedUserRepository struct {
+	instrumented
+	next UserRepository
+}
+
+func InstrumentUserRepository(next UserRepository, observer OperationObserver) UserRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedUserRepository{instrumented: instrumented{repository: "users", observer: observer}, next: next}
+}
+
+func (r *instrumentedUserRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedUserRepository) Upsert(ctx context.Context, user *entities.User) error {
+	ctx, finish := r.start(ctx, "Upsert")
+	err := r.next.Upsert(ctx, user)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedUserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+type instrumentedWebhookDeliveryRepository struct {
+	instrumented
+	next WebhookDeliveryRepository
+}
+
+func InstrumentWebhookDeliveryRepository(next WebhookDeliveryRepository, observer OperationObserver) WebhookDeliveryRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedWebhookDeliveryRepository{instrumented: instrumented{repository: "webhook_deliveries", observer: observer}, next: next}
+}
+
+func (r *instrumentedWebhookDeliveryRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) Insert(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	ctx, finish := r.start(ctx, "Insert")
+	err := r.next.Insert(ctx, deliveries)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	ctx, finish := r.start(ctx, "GetDue")
+	result, err := r.next.GetDue(ctx, now, limit)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) GetByStatus(ctx context.Context, status entities.WebhookDeliveryStatus) ([]*entities.WebhookDelivery, error) {
+	ctx, finish := r.start(ctx, "GetByStatus")
+	result, err := r.next.GetByStatus(ctx, status)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) CountPending(ctx context.Context) (int, error) {
+	ctx, finish := r.start(ctx, "CountPending")
+	result, err := r.next.CountPending(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, finish := r.start(ctx, "Update")
+	err := r.next.Update(ctx, delivery)
+	finish(err)
+	return err
+}
+
+type instrumentedWebhookSubscriptionRepository struct {
+	instrumented
+	next WebhookSubscriptionRepository
+}
+
+func InstrumentWebhookSubscriptionRepository(next WebhookSubscriptionRepository, observer OperationObserver) WebhookSubscriptionRepository {
+	if observer == nil {
+		return next
+	}
+	return &instrumentedWebhookSubscriptionRepository{instrumented: instrumented{repository: "webhook_subscriptions", observer: observer}, next: next}
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) Ping(ctx context.Context) error {
+	ctx, finish := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) Upsert(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	ctx, finish := r.start(ctx, "Upsert")
+	err := r.next.Upsert(ctx, subscription)
+	finish(err)
+	return err
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	ctx, finish := r.start(ctx, "GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	ctx, finish := r.start(ctx, "GetAll")
+	result, err := r.next.GetAll(ctx)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) GetByMerchantID(ctx context.Context, merchantID string) ([]*entities.WebhookSubscription, error) {
+	ctx, finish := r.start(ctx, "GetByMerchantID")
+	result, err := r.next.GetByMerchantID(ctx, merchantID)
+	finish(err)
+	return result, err
+}
+
+func (r *instrumentedWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	ctx, finish := r.start(ctx, "Delete")
+	err := r.next.Delete(ctx, id)
+	finish(err)
+	return err
+}
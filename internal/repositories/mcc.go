@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+//go:embed data/mcc_catalog.json
+var mccCatalogJSON []byte
+
+type MCCRepository interface {
+	Pinger
+	GetAll(ctx context.Context) ([]*entities.MCC, error)
+	GetByCode(ctx context.Context, code string) (*entities.MCC, error)
+	GetByCategory(ctx context.Context, category string) ([]*entities.MCC, error)
+	GetCategories(ctx context.Context) ([]*entities.MCCCategory, error)
+}
+
+type mccCatalog struct {
+	Categories []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"categories"`
+	MCCs []struct {
+		Code        string `json:"code"`
+		Range       string `json:"range"`
+		Description string `json:"description"`
+		Category    string `json:"category"`
+	} `json:"mccs"`
+}
+
+// EmbeddedMCCRepository serves the read-only MCC catalog bundled with the binary.
+type EmbeddedMCCRepository struct {
+	categories []*entities.MCCCategory
+	mccs       []*entities.MCC
+	byCode     map[string]*entities.MCC
+}
+
+func NewEmbeddedMCCRepository() (*EmbeddedMCCRepository, error) {
+	var catalog mccCatalog
+	if err := json.Unmarshal(mccCatalogJSON, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse mcc catalog: %w", err)
+	}
+
+	r := &EmbeddedMCCRepository{
+		categories: make([]*entities.MCCCategory, 0, len(catalog.Categories)),
+		byCode:     make(map[string]*entities.MCC),
+	}
+
+	knownCategories := make(map[string]bool)
+	for _, category := range catalog.Categories {
+		knownCategories[category.Name] = true
+		r.categories = append(r.categories, &entities.MCCCategory{Name: category.Name, Description: category.Description})
+	}
+
+	for _, entry := range catalog.MCCs {
+		codes := []string{entry.Code}
+		if entry.Range != "" {
+			expanded, err := expandCatalogRange(entry.Range)
+			if err != nil {
+				return nil, err
+			}
+			codes = expanded
+		}
+
+		if !knownCategories[entry.Category] {
+			return nil, fmt.Errorf("mcc catalog entry %q references unknown category %q", codes[0], entry.Category)
+		}
+
+		for _, code := range codes {
+			if _, exists := r.byCode[code]; exists {
+				return nil, fmt.Errorf("mcc catalog contains duplicate code %q", code)
+			}
+			mcc := entities.NewMCC(code, entry.Description, entry.Category)
+			r.byCode[code] = mcc
+			r.mccs = append(r.mccs, mcc)
+		}
+	}
+
+	slices.SortFunc(r.mccs, func(a, b *entities.MCC) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return r, nil
+}
+
+func (r *EmbeddedMCCRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func expandCatalogRange(codeRange string) ([]string, error) {
+	from, to, ok := strings.Cut(codeRange, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid mcc catalog range %q", codeRange)
+	}
+
+	start, err := strconv.Atoi(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mcc catalog range %q", codeRange)
+	}
+	end, err := strconv.Atoi(to)
+	if err != nil || end < start {
+		return nil, fmt.Errorf("invalid mcc catalog range %q", codeRange)
+	}
+
+	codes := make([]string, 0, end-start+1)
+	for code := start; code <= end; code++ {
+		codes = append(codes, fmt.Sprintf("%04d", code))
+	}
+	return codes, nil
+}
+
+func (r *EmbeddedMCCRepository) GetAll(ctx context.Context) ([]*entities.MCC, error) {
+	return slices.Clone(r.mccs), nil
+}
+
+func (r *EmbeddedMCCRepository) GetByCode(ctx context.Context, code string) (*entities.MCC, error) {
+	mcc, exists := r.byCode[code]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return mcc, nil
+}
+
+func (r *EmbeddedMCCRepository) GetByCategory(ctx context.Context, category string) ([]*entities.MCC, error) {
+	category = entities.NormalizeMCCCategory(category)
+	if !slices.ContainsFunc(r.categories, func(c *entities.MCCCategory) bool { return c.Name == category }) {
+		return nil, ErrNotFound
+	}
+
+	mccs := make([]*entities.MCC, 0)
+	for _, mcc := range r.mccs {
+		if mcc.Category == category {
+			mccs = append(mccs, mcc)
+		}
+	}
+	return mccs, nil
+}
+
+func (r *EmbeddedMCCRepository) GetCategories(ctx context.Context) ([]*entities.MCCCategory, error) {
+	return slices.Clone(r.categories), nil
+}
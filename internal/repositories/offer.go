@@ -1,37 +1,78 @@
 package repositories
 
 import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Drinnn/eligible-offers-api/internal/entities"
 )
 
 type OfferRepository interface {
-	Upsert(offer *entities.Offer) error
-	GetAll() ([]*entities.Offer, error)
+	Pinger
+	Upsert(ctx context.Context, offer *entities.Offer) error
+	GetAll(ctx context.Context) ([]*entities.Offer, error)
+	GetByID(ctx context.Context, id string) (*entities.Offer, error)
+	// GetActiveAt returns the active offers with StartsAt <= now <= EndsAt. Schedules are not
+	// applied. SQL implementations should serve it from an index on (starts_at, ends_at)
+	// restricted to active offers (see DESIGN.md).
+	GetActiveAt(ctx context.Context, now time.Time) ([]*entities.Offer, error)
+	// GetByMerchantsOrMCCs returns the offers, active or not, for one of the merchants or
+	// whitelisting one of the MCCs: the only offers a transaction with them can match. SQL
+	// implementations should serve it from indexes on merchant_id and the MCC whitelist.
+	GetByMerchantsOrMCCs(ctx context.Context, merchantIDs []string, mccs []string) ([]*entities.Offer, error)
 }
 
 type InMemoryOfferRepository struct {
-	mu     sync.RWMutex
-	offers map[string]*entities.Offer
+	mu         sync.RWMutex
+	offers     map[string]*entities.Offer
+	active     *offerIntervalIndex
+	byMerchant map[string][]*entities.Offer
+	byMCC      map[string][]*entities.Offer
 }
 
 func NewInMemoryOfferRepository() *InMemoryOfferRepository {
 	return &InMemoryOfferRepository{
-		offers: make(map[string]*entities.Offer),
+		offers:     make(map[string]*entities.Offer),
+		active:     newOfferIntervalIndex(nil),
+		byMerchant: make(map[string][]*entities.Offer),
+		byMCC:      make(map[string][]*entities.Offer),
 	}
 }
 
-func (r *InMemoryOfferRepository) Upsert(offer *entities.Offer) error {
+func (r *InMemoryOfferRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryOfferRepository) Upsert(ctx context.Context, offer *entities.Offer) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.offers[offer.ID] = offer
 
+	// Offers are written rarely and read on every eligibility check and ingest, so the
+	// indexes are rebuilt on write rather than updated in place.
+	active := make([]*entities.Offer, 0, len(r.offers))
+	r.byMerchant = make(map[string][]*entities.Offer)
+	r.byMCC = make(map[string][]*entities.Offer)
+	for _, offer := range r.offers {
+		if offer.Active {
+			active = append(active, offer)
+		}
+		r.byMerchant[offer.MerchantID] = append(r.byMerchant[offer.MerchantID], offer)
+		for _, mcc := range slices.Compact(slices.Sorted(slices.Values(offer.MCCWhitelist))) {
+			r.byMCC[mcc] = append(r.byMCC[mcc], offer)
+		}
+	}
+	r.active = newOfferIntervalIndex(active)
+
 	return nil
 }
 
-func (r *InMemoryOfferRepository) GetAll() ([]*entities.Offer, error) {
+func (r *InMemoryOfferRepository) GetAll(ctx context.Context) ([]*entities.Offer, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -41,3 +82,44 @@ func (r *InMemoryOfferRepository) GetAll() ([]*entities.Offer, error) {
 	}
 	return allOffers, nil
 }
+
+func (r *InMemoryOfferRepository) GetByID(ctx context.Context, id string) (*entities.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	offer, exists := r.offers[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return offer, nil
+}
+
+func (r *InMemoryOfferRepository) GetActiveAt(ctx context.Context, now time.Time) ([]*entities.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.active.stab(now), nil
+}
+
+func (r *InMemoryOfferRepository) GetByMerchantsOrMCCs(ctx context.Context, merchantIDs []string, mccs []string) ([]*entities.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[string]*entities.Offer)
+	for _, merchantID := range merchantIDs {
+		for _, offer := range r.byMerchant[merchantID] {
+			found[offer.ID] = offer
+		}
+	}
+	for _, mcc := range mccs {
+		for _, offer := range r.byMCC[mcc] {
+			found[offer.ID] = offer
+		}
+	}
+
+	offers := slices.Collect(maps.Values(found))
+	slices.SortFunc(offers, func(a, b *entities.Offer) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return offers, nil
+}
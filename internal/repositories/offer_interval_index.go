@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+// offerIntervalIndex answers "which offers cover this instant" over [StartsAt, EndsAt].
+// It is an augmented interval tree laid out implicitly over offers sorted by StartsAt:
+// the node of the range [lo, hi) is its midpoint, and maxEndsAt holds the latest EndsAt
+// in that node's subtree, so stabbing queries are O(log N + K) for K results.
+type offerIntervalIndex struct {
+	offers    []*entities.Offer
+	maxEndsAt []time.Time
+}
+
+func newOfferIntervalIndex(offers []*entities.Offer) *offerIntervalIndex {
+	sorted := slices.Clone(offers)
+	slices.SortFunc(sorted, func(a, b *entities.Offer) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	index := &offerIntervalIndex{
+		offers:    sorted,
+		maxEndsAt: make([]time.Time, len(sorted)),
+	}
+	index.build(0, len(sorted))
+	return index
+}
+
+func (i *offerIntervalIndex) build(lo, hi int) time.Time {
+	if lo >= hi {
+		return time.Time{}
+	}
+	mid := (lo + hi) / 2
+
+	maxEndsAt := i.offers[mid].EndsAt
+	for _, childMax := range []time.Time{i.build(lo, mid), i.build(mid+1, hi)} {
+		if childMax.After(maxEndsAt) {
+			maxEndsAt = childMax
+		}
+	}
+	i.maxEndsAt[mid] = maxEndsAt
+	return maxEndsAt
+}
+
+// stab returns the offers with StartsAt <= at <= EndsAt, ordered by StartsAt.
+func (i *offerIntervalIndex) stab(at time.Time) []*entities.Offer {
+	result := make([]*entities.Offer, 0)
+	return i.collect(at, 0, len(i.offers), result)
+}
+
+func (i *offerIntervalIndex) collect(at time.Time, lo, hi int, result []*entities.Offer) []*entities.Offer {
+	if lo >= hi {
+		return result
+	}
+	mid := (lo + hi) / 2
+	if i.maxEndsAt[mid].Before(at) {
+		return result
+	}
+
+	result = i.collect(at, lo, mid, result)
+
+	// Everything from mid onwards starts after mid does.
+	offer := i.offers[mid]
+	if offer.StartsAt.After(at) {
+		return result
+	}
+	if !offer.EndsAt.Before(at) {
+		result = append(result, offer)
+	}
+	return i.collect(at, mid+1, hi, result)
+}
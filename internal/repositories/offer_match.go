@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+// OfferMatchSummary measures a user's transactions matching an offer at an instant.
+type OfferMatchSummary struct {
+	TransactionCount  int // in the offer's lookback window
+	DistinctMerchants int // in the window, only measured for offers with MinDistinctMerchants
+	Streak            int // only measured for offers with a streak
+}
+
+// OfferMatchReader measures a user's progress on offers from the transactions matching them.
+type OfferMatchReader interface {
+	Pinger
+	// GetSummaries measures the user's matches for each of the offers at now, leaving out the
+	// offers the user has no matching transaction for.
+	GetSummaries(ctx context.Context, userID string, offers []*entities.Offer, now time.Time) (map[string]OfferMatchSummary, error)
+}
+
+// OfferMatchRepository is a materialized OfferMatchReader kept up to date as
+// transactions are ingested and offers change.
+type OfferMatchRepository interface {
+	OfferMatchReader
+	// Add records transactions matching the offer. Each transaction is added once: ingestion
+	// only adds the transactions it inserted.
+	Add(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error
+	// ReplaceOffer discards every match recorded for the offer and stores the given ones.
+	ReplaceOffer(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error
+}
+
+// offerMatches is what is kept of a user's transactions matching an offer: their approval
+// times, sorted so windows and streak periods are counted by binary search, and the same per
+// merchant for offers counting distinct merchants.
+type offerMatches struct {
+	approvedAt []time.Time
+	merchants  map[string][]time.Time // nil unless the offer counts distinct merchants
+}
+
+func newOfferMatches(offer *entities.Offer) *offerMatches {
+	matches := &offerMatches{}
+	if offer.MinDistinctMerchants > 0 {
+		matches.merchants = make(map[string][]time.Time)
+	}
+	return matches
+}
+
+func (m *offerMatches) add(transaction *entities.Transaction) {
+	m.approvedAt = insertSorted(m.approvedAt, transaction.ApprovedAt)
+	if m.merchants != nil {
+		m.merchants[transaction.MerchantID] = insertSorted(m.merchants[transaction.MerchantID], transaction.ApprovedAt)
+	}
+}
+
+// summarize measures the matches at now with a binary search per window, plus one per
+// merchant and per streak period for the offers with those rules. Matches kept for an
+// earlier version of the offer without a distinct merchant rule count no merchants until
+// ReplaceOffer rebuilds them.
+func (m *offerMatches) summarize(offer *entities.Offer, now time.Time) OfferMatchSummary {
+	window := offer.LookbackWindow(now)
+	summary := OfferMatchSummary{TransactionCount: countBetween(m.approvedAt, window.Start, window.End)}
+
+	if offer.MinDistinctMerchants > 0 {
+		for _, approvedAt := range m.merchants {
+			if countBetween(approvedAt, window.Start, window.End) > 0 {
+				summary.DistinctMerchants++
+			}
+		}
+	}
+
+	if offer.Streak != nil {
+		summary.Streak = offer.CurrentStreak(func(start, end time.Time) bool {
+			return countBetween(m.approvedAt, start, end) > 0
+		}, now)
+	}
+
+	return summary
+}
+
+func insertSorted(times []time.Time, t time.Time) []time.Time {
+	i, _ := slices.BinarySearchFunc(times, t, time.Time.Compare)
+	return slices.Insert(times, i, t)
+}
+
+// countBetween counts the sorted times within [start, end].
+func countBetween(times []time.Time, start, end time.Time) int {
+	from := sort.Search(len(times), func(i int) bool { return !times[i].Before(start) })
+	to := sort.Search(len(times), func(i int) bool { return times[i].After(end) })
+	return max(0, to-from)
+}
+
+// offerMatchRetention returns the earliest approval time a match can count for the offer, so
+// older ones need not be kept: offers are only evaluated while live, and window starts only
+// move forward, so none starts before the window at StartsAt. The two days of slack cover
+// windows taken in another zone than StartsAt's when the offer has no Location. Streaks walk
+// back without bound, so streak offers keep every match.
+func offerMatchRetention(offer *entities.Offer) time.Time {
+	if offer.Streak != nil {
+		return time.Time{}
+	}
+	return offer.LookbackWindow(offer.StartsAt).Start.AddDate(0, 0, -2)
+}
+
+// InMemoryOfferMatchRepository keeps, per user and offer, the approval times of the matches
+// that can still count: none approved after the offer ends, nor before its earliest window.
+type InMemoryOfferMatchRepository struct {
+	mu      sync.RWMutex
+	matches map[string]map[string]*offerMatches // user ID -> offer ID -> matches
+	users   map[string]map[string]bool          // offer ID -> user IDs with matches
+}
+
+func NewInMemoryOfferMatchRepository() *InMemoryOfferMatchRepository {
+	return &InMemoryOfferMatchRepository{
+		matches: make(map[string]map[string]*offerMatches),
+		users:   make(map[string]map[string]bool),
+	}
+}
+
+func (r *InMemoryOfferMatchRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryOfferMatchRepository) Add(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.insert(offer, transactions)
+
+	return nil
+}
+
+func (r *InMemoryOfferMatchRepository) ReplaceOffer(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for userID := range r.users[offer.ID] {
+		delete(r.matches[userID], offer.ID)
+	}
+	delete(r.users, offer.ID)
+
+	r.insert(offer, transactions)
+
+	return nil
+}
+
+func (r *InMemoryOfferMatchRepository) insert(offer *entities.Offer, transactions []*entities.Transaction) {
+	retainFrom := offerMatchRetention(offer)
+	for _, transaction := range transactions {
+		if transaction.ApprovedAt.Before(retainFrom) || transaction.ApprovedAt.After(offer.EndsAt) {
+			continue
+		}
+
+		if r.users[offer.ID] == nil {
+			r.users[offer.ID] = make(map[string]bool)
+		}
+		r.users[offer.ID][transaction.UserID] = true
+
+		byOffer, exists := r.matches[transaction.UserID]
+		if !exists {
+			byOffer = make(map[string]*offerMatches)
+			r.matches[transaction.UserID] = byOffer
+		}
+		matches, exists := byOffer[offer.ID]
+		if !exists {
+			matches = newOfferMatches(offer)
+			byOffer[offer.ID] = matches
+		}
+		matches.add(transaction)
+	}
+}
+
+func (r *InMemoryOfferMatchRepository) GetSummaries(ctx context.Context, userID string, offers []*entities.Offer, now time.Time) (map[string]OfferMatchSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byOffer := r.matches[userID]
+	summaries := make(map[string]OfferMatchSummary, min(len(offers), len(byOffer)))
+	for _, offer := range offers {
+		if matches, exists := byOffer[offer.ID]; exists {
+			summaries[offer.ID] = matches.summarize(offer, now)
+		}
+	}
+	return summaries, nil
+}
+
+// ScanOfferMatchReader computes matches on every read by checking all of the user's
+// transactions against the offers.
+type ScanOfferMatchReader struct {
+	transactionRepository TransactionRepository
+}
+
+func NewScanOfferMatchReader(transactionRepository TransactionRepository) *ScanOfferMatchReader {
+	return &ScanOfferMatchReader{
+		transactionRepository: transactionRepository,
+	}
+}
+
+func (r *ScanOfferMatchReader) Ping(ctx context.Context) error {
+	return r.transactionRepository.Ping(ctx)
+}
+
+func (r *ScanOfferMatchReader) GetSummaries(ctx context.Context, userID string, offers []*entities.Offer, now time.Time) (map[string]OfferMatchSummary, error) {
+	transactions, err := r.transactionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]OfferMatchSummary)
+	for _, offer := range offers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var matches *offerMatches
+		for _, transaction := range transactions {
+			if !offer.Matches(transaction) {
+				continue
+			}
+			if matches == nil {
+				matches = newOfferMatches(offer)
+			}
+			matches.add(transaction)
+		}
+		if matches != nil {
+			summaries[offer.ID] = matches.summarize(offer, now)
+		}
+	}
+	return summaries, nil
+}
@@ -0,0 +1,68 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+func TestInMemoryOfferMatchRepository_KeepsOnlyMatchesThatCanCount(t *testing.T) {
+	repo := repositories.NewInMemoryOfferMatchRepository()
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	transaction := func(id, userID, merchantID string, approvedAt time.Time) *entities.Transaction {
+		return entities.NewTransaction(id, userID, merchantID, "5812", 1000, approvedAt)
+	}
+
+	// Given: A 7-day offer live for 10 days, counting distinct merchants, and a daily streak offer
+	offer := &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, MinDistinctMerchants: 2, LookbackDays: 7, StartsAt: base, EndsAt: base.AddDate(0, 0, 10)}
+	streakOffer := &entities.Offer{ID: "offer-2", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, LookbackDays: 7, StartsAt: base, EndsAt: base.AddDate(0, 0, 10), Streak: &entities.Streak{Period: entities.StreakDaily, Count: 3}}
+
+	// When: Matches are added before its first window, inside it, and after the offer ends
+	repo.Add(t.Context(), offer, []*entities.Transaction{
+		transaction("txn-1", "user-1", "merchant-1", base.AddDate(0, 0, -30)),
+		transaction("txn-2", "user-1", "merchant-1", base.AddDate(0, 0, -3)),
+		transaction("txn-3", "user-1", "merchant-2", base.AddDate(0, 0, 1)),
+		transaction("txn-4", "user-1", "merchant-3", base.AddDate(0, 0, 20)),
+		transaction("txn-5", "user-2", "merchant-1", base.AddDate(0, 0, -30)),
+	})
+	repo.Add(t.Context(), streakOffer, []*entities.Transaction{
+		transaction("txn-1", "user-1", "merchant-1", base.AddDate(0, 0, -1)),
+		transaction("txn-2", "user-1", "merchant-1", base.AddDate(0, 0, -2)),
+		transaction("txn-3", "user-1", "merchant-1", base.AddDate(0, 0, -30)),
+	})
+
+	// Then: The window count and distinct merchants are measured from the kept matches
+	summaries, err := repo.GetSummaries(t.Context(), "user-1", []*entities.Offer{offer}, base.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if summary := summaries["offer-1"]; summary.TransactionCount != 2 || summary.DistinctMerchants != 2 {
+		t.Errorf("expected 2 transactions at 2 merchants, got %+v", summary)
+	}
+	// And: The streak walks back from the last day with a match
+	summaries, _ = repo.GetSummaries(t.Context(), "user-1", []*entities.Offer{streakOffer}, base)
+	if summary := summaries["offer-2"]; summary.Streak != 2 {
+		t.Errorf("expected a 2-day streak, got %+v", summary)
+	}
+	// And: Streak matches are kept however old, since streaks have no window
+	summaries, _ = repo.GetSummaries(t.Context(), "user-1", []*entities.Offer{streakOffer}, base.AddDate(0, 0, -30))
+	if summary := summaries["offer-2"]; summary.Streak != 1 {
+		t.Errorf("expected a 1-day streak 30 days back, got %+v", summary)
+	}
+
+	// And: Matches that can never count were not kept, even when asked with a wider window
+	widened := *offer
+	widened.LookbackDays = 60
+	widened.EndsAt = base.AddDate(0, 0, 30)
+	summaries, _ = repo.GetSummaries(t.Context(), "user-1", []*entities.Offer{&widened}, base.AddDate(0, 0, 25))
+	if summary := summaries["offer-1"]; summary.TransactionCount != 2 {
+		t.Errorf("expected the matches before the first window and after the end to be dropped, got %+v", summary)
+	}
+	// And: A user whose every match was dropped has no summary for the offer
+	summaries, _ = repo.GetSummaries(t.Context(), "user-2", []*entities.Offer{offer}, base.AddDate(0, 0, 1))
+	if _, exists := summaries["offer-1"]; exists {
+		t.Errorf("expected no summary for user-2, got %+v", summaries)
+	}
+}
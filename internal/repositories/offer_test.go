@@ -0,0 +1,107 @@
+package repositories_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+func TestInMemoryOfferRepository_GetActiveAtMatchesFullScan(t *testing.T) {
+	repo := repositories.NewInMemoryOfferRepository()
+	rng := rand.New(rand.NewSource(1))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Given: Offers with random date ranges, some inactive and some re-upserted
+	for range 500 {
+		startsAt := base.Add(time.Duration(rng.Intn(365*24)) * time.Hour)
+		repo.Upsert(t.Context(), &entities.Offer{
+			ID:       fmt.Sprintf("offer-%d", rng.Intn(400)),
+			Active:   rng.Intn(5) > 0,
+			StartsAt: startsAt,
+			EndsAt:   startsAt.Add(time.Duration(rng.Intn(60*24)) * time.Hour),
+		})
+	}
+	all, _ := repo.GetAll(t.Context())
+
+	for range 200 {
+		now := base.Add(time.Duration(rng.Intn(400*24)) * time.Hour)
+
+		// When: We get the offers active at a random instant
+		active, err := repo.GetActiveAt(t.Context(), now)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Then: They are exactly the active offers whose range covers it
+		expected := make([]string, 0)
+		for _, offer := range all {
+			if offer.Active && !now.Before(offer.StartsAt) && !now.After(offer.EndsAt) {
+				expected = append(expected, offer.ID)
+			}
+		}
+		got := make([]string, 0, len(active))
+		for _, offer := range active {
+			got = append(got, offer.ID)
+		}
+		slices.Sort(expected)
+		slices.Sort(got)
+		if !slices.Equal(expected, got) {
+			t.Fatalf("at %v expected [%s], got [%s]", now, strings.Join(expected, ","), strings.Join(got, ","))
+		}
+	}
+}
+
+func TestInMemoryOfferRepository_GetByMerchantsOrMCCsMatchesFullScan(t *testing.T) {
+	repo := repositories.NewInMemoryOfferRepository()
+	rng := rand.New(rand.NewSource(1))
+	merchants := []string{"merchant-0", "merchant-1", "merchant-2", "merchant-3", "merchant-4"}
+	mccs := []string{"5411", "5541", "5812", "5814", "5912"}
+
+	// Given: Offers with random merchants and MCC whitelists, some inactive and some re-upserted
+	for range 300 {
+		whitelist := make([]string, rng.Intn(3))
+		for i := range whitelist {
+			whitelist[i] = mccs[rng.Intn(len(mccs))]
+		}
+		repo.Upsert(t.Context(), &entities.Offer{
+			ID:           fmt.Sprintf("offer-%d", rng.Intn(200)),
+			MerchantID:   merchants[rng.Intn(len(merchants))],
+			MCCWhitelist: whitelist,
+			Active:       rng.Intn(5) > 0,
+		})
+	}
+	all, _ := repo.GetAll(t.Context())
+
+	for range 100 {
+		queriedMerchants := []string{merchants[rng.Intn(len(merchants))]}
+		queriedMCCs := []string{mccs[rng.Intn(len(mccs))], mccs[rng.Intn(len(mccs))]}
+
+		// When: We get the offers for random merchants and MCCs
+		offers, err := repo.GetByMerchantsOrMCCs(t.Context(), queriedMerchants, queriedMCCs)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Then: They are exactly the offers for one of the merchants or whitelisting one of the MCCs, once each
+		expected := make([]string, 0)
+		for _, offer := range all {
+			if slices.Contains(queriedMerchants, offer.MerchantID) || slices.ContainsFunc(queriedMCCs, func(mcc string) bool { return slices.Contains(offer.MCCWhitelist, mcc) }) {
+				expected = append(expected, offer.ID)
+			}
+		}
+		got := make([]string, 0, len(offers))
+		for _, offer := range offers {
+			got = append(got, offer.ID)
+		}
+		slices.Sort(expected)
+		if !slices.Equal(expected, got) {
+			t.Fatalf("for %v and %v expected [%s], got [%s]", queriedMerchants, queriedMCCs, strings.Join(expected, ","), strings.Join(got, ","))
+		}
+	}
+}
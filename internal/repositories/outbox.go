@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type OutboxRepository interface {
+	Pinger
+	Append(ctx context.Context, events []*entities.OfferEvent) error
+	// GetPending returns up to limit undispatched events in the order they were appended.
+	GetPending(ctx context.Context, limit int) ([]*entities.OfferEvent, error)
+	CountPending(ctx context.Context) (int, error)
+	MarkDispatched(ctx context.Context, eventIDs []string, at time.Time) error
+}
+
+type InMemoryOutboxRepository struct {
+	mu      sync.RWMutex
+	pending []*entities.OfferEvent
+}
+
+func NewInMemoryOutboxRepository() *InMemoryOutboxRepository {
+	return &InMemoryOutboxRepository{
+		pending: make([]*entities.OfferEvent, 0),
+	}
+}
+
+func (r *InMemoryOutboxRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryOutboxRepository) Append(ctx context.Context, events []*entities.OfferEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(r.pending, events...)
+
+	return nil
+}
+
+func (r *InMemoryOutboxRepository) GetPending(ctx context.Context, limit int) ([]*entities.OfferEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := min(limit, len(r.pending))
+	events := make([]*entities.OfferEvent, count)
+	copy(events, r.pending[:count])
+
+	return events, nil
+}
+
+func (r *InMemoryOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.pending), nil
+}
+
+func (r *InMemoryOutboxRepository) MarkDispatched(ctx context.Context, eventIDs []string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dispatched := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		dispatched[id] = true
+	}
+
+	pending := make([]*entities.OfferEvent, 0, len(r.pending))
+	for _, event := range r.pending {
+		if !dispatched[event.ID] {
+			pending = append(pending, event)
+		}
+	}
+	r.pending = pending
+
+	return nil
+}
+
+// outboxRecord is one line of the outbox log: either appended events or dispatched event IDs.
+type outboxRecord struct {
+	Events       []*outboxEventRecord `json:"events,omitempty"`
+	DispatchedAt *time.Time           `json:"dispatched_at,omitempty"`
+	EventIDs     []string             `json:"event_ids,omitempty"`
+}
+
+type outboxEventRecord struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	UserID     string    `json:"user_id"`
+	OfferID    string    `json:"offer_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// FileOutboxRepository keeps pending events in memory and writes every change to an append-only
+// JSON lines log, which is replayed on startup so undispatched events survive restarts.
+type FileOutboxRepository struct {
+	*InMemoryOutboxRepository
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileOutboxRepository(path string) (*FileOutboxRepository, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox log: %w", err)
+	}
+
+	r := &FileOutboxRepository{
+		InMemoryOutboxRepository: NewInMemoryOutboxRepository(),
+		file:                     file,
+	}
+	if err := r.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *FileOutboxRepository) replay() error {
+	scanner := bufio.NewScanner(r.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record outboxRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("parse outbox log line %d: %w", line, err)
+		}
+
+		if record.DispatchedAt != nil {
+			r.InMemoryOutboxRepository.MarkDispatched(context.Background(), record.EventIDs, *record.DispatchedAt)
+			continue
+		}
+		events := make([]*entities.OfferEvent, len(record.Events))
+		for i, event := range record.Events {
+			events[i] = &entities.OfferEvent{
+				ID:         event.ID,
+				Type:       entities.OfferEventType(event.Type),
+				UserID:     event.UserID,
+				OfferID:    event.OfferID,
+				OccurredAt: event.OccurredAt,
+			}
+		}
+		r.InMemoryOutboxRepository.Append(context.Background(), events)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read outbox log: %w", err)
+	}
+	return nil
+}
+
+func (r *FileOutboxRepository) Append(ctx context.Context, events []*entities.OfferEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	record := outboxRecord{Events: make([]*outboxEventRecord, len(events))}
+	for i, event := range events {
+		record.Events[i] = &outboxEventRecord{
+			ID:         event.ID,
+			Type:       string(event.Type),
+			UserID:     event.UserID,
+			OfferID:    event.OfferID,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(record); err != nil {
+		return err
+	}
+	return r.InMemoryOutboxRepository.Append(ctx, events)
+}
+
+func (r *FileOutboxRepository) MarkDispatched(ctx context.Context, eventIDs []string, at time.Time) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(outboxRecord{DispatchedAt: &at, EventIDs: eventIDs}); err != nil {
+		return err
+	}
+	return r.InMemoryOutboxRepository.MarkDispatched(ctx, eventIDs, at)
+}
+
+// Ping fails once the log is closed or can no longer be read.
+func (r *FileOutboxRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.file.Stat(); err != nil {
+		return fmt.Errorf("stat outbox log: %w", err)
+	}
+	return nil
+}
+
+// write appends the record and syncs it to disk before the in-memory state is updated.
+func (r *FileOutboxRepository) write(record outboxRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode outbox record: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write outbox log: %w", err)
+	}
+	if err := r.file.Sync(); err != nil {
+		return fmt.Errorf("sync outbox log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the log to disk and closes it.
+func (r *FileOutboxRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Sync(); err != nil {
+		r.file.Close()
+		return fmt.Errorf("sync outbox log: %w", err)
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// Pinger is embedded in every repository interface so readiness checks can reach each backend.
+type Pinger interface {
+	// Ping returns an error when the backend cannot serve requests.
+	Ping(ctx context.Context) error
+}
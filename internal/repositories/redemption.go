@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type RedemptionRepository interface {
+	Pinger
+	// Activate stores the activation unless the user already activated the offer,
+	// in which case the existing activation is returned.
+	Activate(ctx context.Context, activation *entities.Activation) (*entities.Activation, error)
+	GetActivation(ctx context.Context, userID, offerID string) (*entities.Activation, error)
+	GetActivationsByUserID(ctx context.Context, userID string) ([]*entities.Activation, error)
+	// InsertRedemption fails with ErrAlreadyRedeemed when singleUse is set and
+	// the user already redeemed the offer.
+	InsertRedemption(ctx context.Context, redemption *entities.Redemption, singleUse bool) error
+	GetRedemptionsByUserID(ctx context.Context, userID string) ([]*entities.Redemption, error)
+}
+
+type InMemoryRedemptionRepository struct {
+	mu          sync.RWMutex
+	activations map[string]map[string]*entities.Activation // user ID -> offer ID -> activation
+	redemptions map[string][]*entities.Redemption          // user ID -> redemptions
+}
+
+func NewInMemoryRedemptionRepository() *InMemoryRedemptionRepository {
+	return &InMemoryRedemptionRepository{
+		activations: make(map[string]map[string]*entities.Activation),
+		redemptions: make(map[string][]*entities.Redemption),
+	}
+}
+
+func (r *InMemoryRedemptionRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryRedemptionRepository) Activate(ctx context.Context, activation *entities.Activation) (*entities.Activation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	userActivations, exists := r.activations[activation.UserID]
+	if !exists {
+		userActivations = make(map[string]*entities.Activation)
+		r.activations[activation.UserID] = userActivations
+	}
+	if existing, exists := userActivations[activation.OfferID]; exists {
+		return existing, nil
+	}
+
+	userActivations[activation.OfferID] = activation
+	return activation, nil
+}
+
+func (r *InMemoryRedemptionRepository) GetActivation(ctx context.Context, userID, offerID string) (*entities.Activation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	activation, exists := r.activations[userID][offerID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return activation, nil
+}
+
+func (r *InMemoryRedemptionRepository) GetActivationsByUserID(ctx context.Context, userID string) ([]*entities.Activation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	activations := make([]*entities.Activation, 0, len(r.activations[userID]))
+	for _, activation := range r.activations[userID] {
+		activations = append(activations, activation)
+	}
+	return activations, nil
+}
+
+func (r *InMemoryRedemptionRepository) InsertRedemption(ctx context.Context, redemption *entities.Redemption, singleUse bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if singleUse {
+		for _, existing := range r.redemptions[redemption.UserID] {
+			if existing.OfferID == redemption.OfferID {
+				return ErrAlreadyRedeemed
+			}
+		}
+	}
+
+	r.redemptions[redemption.UserID] = append(r.redemptions[redemption.UserID], redemption)
+	return nil
+}
+
+func (r *InMemoryRedemptionRepository) GetRedemptionsByUserID(ctx context.Context, userID string) ([]*entities.Redemption, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	redemptions := make([]*entities.Redemption, len(r.redemptions[userID]))
+	copy(redemptions, r.redemptions[userID])
+	return redemptions, nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type SegmentRepository interface {
+	Pinger
+	Upsert(ctx context.Context, segment *entities.Segment) error
+	GetByID(ctx context.Context, id string) (*entities.Segment, error)
+	GetAll(ctx context.Context) ([]*entities.Segment, error)
+	Delete(ctx context.Context, id string) error
+	// AddMembers adds users to the segment and returns how many were not members yet.
+	AddMembers(ctx context.Context, segmentID string, userIDs []string) (int, error)
+	ReplaceMembers(ctx context.Context, segmentID string, userIDs []string) error
+	CountMembers(ctx context.Context, segmentID string) (int, error)
+	GetSegmentIDsByUserID(ctx context.Context, userID string) ([]string, error)
+}
+
+type InMemorySegmentRepository struct {
+	mu       sync.RWMutex
+	segments map[string]*entities.Segment
+	members  map[string]map[string]bool // segment ID -> user IDs
+}
+
+func NewInMemorySegmentRepository() *InMemorySegmentRepository {
+	return &InMemorySegmentRepository{
+		segments: make(map[string]*entities.Segment),
+		members:  make(map[string]map[string]bool),
+	}
+}
+
+func (r *InMemorySegmentRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemorySegmentRepository) Upsert(ctx context.Context, segment *entities.Segment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.segments[segment.ID] = segment
+	if _, exists := r.members[segment.ID]; !exists {
+		r.members[segment.ID] = make(map[string]bool)
+	}
+
+	return nil
+}
+
+func (r *InMemorySegmentRepository) GetByID(ctx context.Context, id string) (*entities.Segment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segment, exists := r.segments[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return segment, nil
+}
+
+func (r *InMemorySegmentRepository) GetAll(ctx context.Context) ([]*entities.Segment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segments := make([]*entities.Segment, 0, len(r.segments))
+	for _, segment := range r.segments {
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func (r *InMemorySegmentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.segments[id]; !exists {
+		return ErrNotFound
+	}
+	delete(r.segments, id)
+	delete(r.members, id)
+
+	return nil
+}
+
+func (r *InMemorySegmentRepository) AddMembers(ctx context.Context, segmentID string, userIDs []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, exists := r.members[segmentID]
+	if !exists {
+		return 0, ErrNotFound
+	}
+
+	added := 0
+	for _, userID := range userIDs {
+		if !members[userID] {
+			members[userID] = true
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (r *InMemorySegmentRepository) ReplaceMembers(ctx context.Context, segmentID string, userIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.members[segmentID]; !exists {
+		return ErrNotFound
+	}
+
+	members := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		members[userID] = true
+	}
+	r.members[segmentID] = members
+
+	return nil
+}
+
+func (r *InMemorySegmentRepository) CountMembers(ctx context.Context, segmentID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members, exists := r.members[segmentID]
+	if !exists {
+		return 0, ErrNotFound
+	}
+	return len(members), nil
+}
+
+func (r *InMemorySegmentRepository) GetSegmentIDsByUserID(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segmentIDs := make([]string, 0)
+	for segmentID, members := range r.members {
+		if members[userID] {
+			segmentIDs = append(segmentIDs, segmentID)
+		}
+	}
+	return segmentIDs, nil
+}
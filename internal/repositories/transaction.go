@@ -1,14 +1,21 @@
 package repositories
 
 import (
+	"context"
 	"sync"
 
 	"github.com/Drinnn/eligible-offers-api/internal/entities"
 )
 
 type TransactionRepository interface {
-	Insert(transactions []*entities.Transaction) (int, error)
-	GetByUserID(userID string) ([]*entities.Transaction, error)
+	Pinger
+	// Insert stores transactions whose IDs are new and returns them; resent IDs are ignored.
+	Insert(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error)
+	GetByUserID(ctx context.Context, userID string) ([]*entities.Transaction, error)
+	GetUserIDs(ctx context.Context) ([]string, error)
+	GetAll(ctx context.Context) ([]*entities.Transaction, error)
+	// HasMerchant reports whether any transaction was made at the merchant.
+	HasMerchant(ctx context.Context, merchantID string) (bool, error)
 }
 
 type InMemoryTransactionRepository struct {
@@ -22,22 +29,26 @@ func NewInMemoryTransactionRepository() *InMemoryTransactionRepository {
 	}
 }
 
-func (r *InMemoryTransactionRepository) Insert(transactions []*entities.Transaction) (int, error) {
+func (r *InMemoryTransactionRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryTransactionRepository) Insert(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	inserted := 0
+	inserted := make([]*entities.Transaction, 0, len(transactions))
 	for _, transaction := range transactions {
 		if _, exists := r.transactions[transaction.ID]; !exists {
 			r.transactions[transaction.ID] = transaction
-			inserted++
+			inserted = append(inserted, transaction)
 		}
 	}
 
 	return inserted, nil
 }
 
-func (r *InMemoryTransactionRepository) GetByUserID(userID string) ([]*entities.Transaction, error) {
+func (r *InMemoryTransactionRepository) GetByUserID(ctx context.Context, userID string) ([]*entities.Transaction, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -49,3 +60,41 @@ func (r *InMemoryTransactionRepository) GetByUserID(userID string) ([]*entities.
 	}
 	return transactions, nil
 }
+
+func (r *InMemoryTransactionRepository) GetUserIDs(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	userIDs := make([]string, 0)
+	for _, transaction := range r.transactions {
+		if !seen[transaction.UserID] {
+			seen[transaction.UserID] = true
+			userIDs = append(userIDs, transaction.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *InMemoryTransactionRepository) GetAll(ctx context.Context) ([]*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transactions := make([]*entities.Transaction, 0, len(r.transactions))
+	for _, transaction := range r.transactions {
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func (r *InMemoryTransactionRepository) HasMerchant(ctx context.Context, merchantID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, transaction := range r.transactions {
+		if transaction.MerchantID == merchantID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
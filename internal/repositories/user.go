@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type UserRepository interface {
+	Pinger
+	Upsert(ctx context.Context, user *entities.User) error
+	GetByID(ctx context.Context, id string) (*entities.User, error)
+}
+
+type InMemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]*entities.User
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{
+		users: make(map[string]*entities.User),
+	}
+}
+
+func (r *InMemoryUserRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryUserRepository) Upsert(ctx context.Context, user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = user
+
+	return nil
+}
+
+func (r *InMemoryUserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return user, nil
+}
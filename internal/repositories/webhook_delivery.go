@@ -0,0 +1,351 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type WebhookDeliveryRepository interface {
+	Pinger
+	// Insert stores the deliveries, skipping any for a subscription and event already stored,
+	// so batches the outbox re-sends after another sink failed are not delivered twice.
+	Insert(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	GetByID(ctx context.Context, id string) (*entities.WebhookDelivery, error)
+	// GetDue returns up to limit pending deliveries whose next attempt is at or before now, oldest first.
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	GetByStatus(ctx context.Context, status entities.WebhookDeliveryStatus) ([]*entities.WebhookDelivery, error)
+	CountPending(ctx context.Context) (int, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
+
+type webhookDeliveryKey struct {
+	subscriptionID string
+	eventID        string
+}
+
+type InMemoryWebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[string]*entities.WebhookDelivery
+	keys       map[webhookDeliveryKey]bool
+	pending    int
+}
+
+func NewInMemoryWebhookDeliveryRepository() *InMemoryWebhookDeliveryRepository {
+	return &InMemoryWebhookDeliveryRepository{
+		deliveries: make(map[string]*entities.WebhookDelivery),
+		keys:       make(map[webhookDeliveryKey]bool),
+	}
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Insert(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		key := webhookDeliveryKey{subscriptionID: delivery.SubscriptionID, eventID: delivery.EventID}
+		if r.keys[key] {
+			continue
+		}
+		r.keys[key] = true
+		stored := *delivery
+		r.deliveries[delivery.ID] = &stored
+		if stored.Status == entities.WebhookDeliveryPending {
+			r.pending++
+		}
+	}
+
+	return nil
+}
+
+// unseen returns the deliveries Insert would store.
+func (r *InMemoryWebhookDeliveryRepository) unseen(deliveries []*entities.WebhookDelivery) []*entities.WebhookDelivery {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unseen := make([]*entities.WebhookDelivery, 0, len(deliveries))
+	seen := make(map[webhookDeliveryKey]bool, len(deliveries))
+	for _, delivery := range deliveries {
+		key := webhookDeliveryKey{subscriptionID: delivery.SubscriptionID, eventID: delivery.EventID}
+		if r.keys[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unseen = append(unseen, delivery)
+	}
+	return unseen
+}
+
+func (r *InMemoryWebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	snapshot := *delivery
+	return &snapshot, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*entities.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entities.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			snapshot := *delivery
+			due = append(due, &snapshot)
+		}
+	}
+	sortDeliveries(due)
+
+	return due[:min(limit, len(due))], nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) GetByStatus(ctx context.Context, status entities.WebhookDeliveryStatus) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*entities.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == status {
+			snapshot := *delivery
+			deliveries = append(deliveries, &snapshot)
+		}
+	}
+	sortDeliveries(deliveries)
+
+	return deliveries, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) CountPending(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.pending, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.deliveries[delivery.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	if previous.Status == entities.WebhookDeliveryPending {
+		r.pending--
+	}
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	if stored.Status == entities.WebhookDeliveryPending {
+		r.pending++
+	}
+
+	return nil
+}
+
+func sortDeliveries(deliveries []*entities.WebhookDelivery) {
+	slices.SortFunc(deliveries, func(a, b *entities.WebhookDelivery) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// webhookDeliveryLogRecord is one line of the delivery log: either inserted deliveries or an
+// updated one.
+type webhookDeliveryLogRecord struct {
+	Inserted []*webhookDeliveryRecord `json:"inserted,omitempty"`
+	Updated  *webhookDeliveryRecord   `json:"updated,omitempty"`
+}
+
+type webhookDeliveryRecord struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+func newWebhookDeliveryRecord(delivery *entities.WebhookDelivery) *webhookDeliveryRecord {
+	return &webhookDeliveryRecord{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func (r *webhookDeliveryRecord) delivery() *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:             r.ID,
+		SubscriptionID: r.SubscriptionID,
+		EventID:        r.EventID,
+		EventType:      entities.OfferEventType(r.EventType),
+		Payload:        r.Payload,
+		Status:         entities.WebhookDeliveryStatus(r.Status),
+		Attempts:       r.Attempts,
+		NextAttemptAt:  r.NextAttemptAt,
+		LastStatusCode: r.LastStatusCode,
+		LastError:      r.LastError,
+		CreatedAt:      r.CreatedAt,
+		DeliveredAt:    r.DeliveredAt,
+	}
+}
+
+// FileWebhookDeliveryRepository keeps deliveries in memory and writes every change to an
+// append-only JSON lines log, which is replayed on startup so pending retries and dead
+// letters survive restarts.
+type FileWebhookDeliveryRepository struct {
+	*InMemoryWebhookDeliveryRepository
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileWebhookDeliveryRepository(path string) (*FileWebhookDeliveryRepository, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open webhook delivery log: %w", err)
+	}
+
+	r := &FileWebhookDeliveryRepository{
+		InMemoryWebhookDeliveryRepository: NewInMemoryWebhookDeliveryRepository(),
+		file:                              file,
+	}
+	if err := r.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *FileWebhookDeliveryRepository) replay() error {
+	scanner := bufio.NewScanner(r.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record webhookDeliveryLogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("parse webhook delivery log line %d: %w", line, err)
+		}
+
+		if record.Updated != nil {
+			r.InMemoryWebhookDeliveryRepository.Update(context.Background(), record.Updated.delivery())
+			continue
+		}
+		deliveries := make([]*entities.WebhookDelivery, len(record.Inserted))
+		for i, inserted := range record.Inserted {
+			deliveries[i] = inserted.delivery()
+		}
+		r.InMemoryWebhookDeliveryRepository.Insert(context.Background(), deliveries)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read webhook delivery log: %w", err)
+	}
+	return nil
+}
+
+func (r *FileWebhookDeliveryRepository) Insert(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries = r.unseen(deliveries)
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	record := webhookDeliveryLogRecord{Inserted: make([]*webhookDeliveryRecord, len(deliveries))}
+	for i, delivery := range deliveries {
+		record.Inserted[i] = newWebhookDeliveryRecord(delivery)
+	}
+	if err := r.write(record); err != nil {
+		return err
+	}
+	return r.InMemoryWebhookDeliveryRepository.Insert(ctx, deliveries)
+}
+
+func (r *FileWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.InMemoryWebhookDeliveryRepository.GetByID(ctx, delivery.ID); err != nil {
+		return err
+	}
+	if err := r.write(webhookDeliveryLogRecord{Updated: newWebhookDeliveryRecord(delivery)}); err != nil {
+		return err
+	}
+	return r.InMemoryWebhookDeliveryRepository.Update(ctx, delivery)
+}
+
+// Ping fails once the log is closed or can no longer be read.
+func (r *FileWebhookDeliveryRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.file.Stat(); err != nil {
+		return fmt.Errorf("stat webhook delivery log: %w", err)
+	}
+	return nil
+}
+
+// write appends the record and syncs it to disk before the in-memory state is updated.
+func (r *FileWebhookDeliveryRepository) write(record webhookDeliveryLogRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode webhook delivery record: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write webhook delivery log: %w", err)
+	}
+	if err := r.file.Sync(); err != nil {
+		return fmt.Errorf("sync webhook delivery log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the log to disk and closes it.
+func (r *FileWebhookDeliveryRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Sync(); err != nil {
+		r.file.Close()
+		return fmt.Errorf("sync webhook delivery log: %w", err)
+	}
+	return r.file.Close()
+}
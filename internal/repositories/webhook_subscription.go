@@ -0,0 +1,248 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+type WebhookSubscriptionRepository interface {
+	Pinger
+	Upsert(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetByID(ctx context.Context, id string) (*entities.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	GetByMerchantID(ctx context.Context, merchantID string) ([]*entities.WebhookSubscription, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type InMemoryWebhookSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*entities.WebhookSubscription
+}
+
+func NewInMemoryWebhookSubscriptionRepository() *InMemoryWebhookSubscriptionRepository {
+	return &InMemoryWebhookSubscriptionRepository{
+		subscriptions: make(map[string]*entities.WebhookSubscription),
+	}
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Upsert(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[subscription.ID] = subscription
+
+	return nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, exists := r.subscriptions[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return subscription, nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := make([]*entities.WebhookSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) GetByMerchantID(ctx context.Context, merchantID string) ([]*entities.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := make([]*entities.WebhookSubscription, 0)
+	for _, subscription := range r.subscriptions {
+		if subscription.MerchantID == merchantID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[id]; !exists {
+		return ErrNotFound
+	}
+	delete(r.subscriptions, id)
+
+	return nil
+}
+
+// webhookSubscriptionLogRecord is one line of the subscription log: either an upserted
+// subscription or the ID of a deleted one.
+type webhookSubscriptionLogRecord struct {
+	Upserted *webhookSubscriptionRecord `json:"upserted,omitempty"`
+	Deleted  string                     `json:"deleted,omitempty"`
+}
+
+type webhookSubscriptionRecord struct {
+	ID         string   `json:"id"`
+	MerchantID string   `json:"merchant_id"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+func newWebhookSubscriptionRecord(subscription *entities.WebhookSubscription) *webhookSubscriptionRecord {
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	return &webhookSubscriptionRecord{
+		ID:         subscription.ID,
+		MerchantID: subscription.MerchantID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: eventTypes,
+	}
+}
+
+func (r *webhookSubscriptionRecord) subscription() *entities.WebhookSubscription {
+	eventTypes := make([]entities.OfferEventType, len(r.EventTypes))
+	for i, eventType := range r.EventTypes {
+		eventTypes[i] = entities.OfferEventType(eventType)
+	}
+	return &entities.WebhookSubscription{
+		ID:         r.ID,
+		MerchantID: r.MerchantID,
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: eventTypes,
+	}
+}
+
+// FileWebhookSubscriptionRepository keeps subscriptions in memory and writes every change to
+// an append-only JSON lines log, which is replayed on startup so deliveries restored from the
+// delivery log still find their subscription.
+type FileWebhookSubscriptionRepository struct {
+	*InMemoryWebhookSubscriptionRepository
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileWebhookSubscriptionRepository(path string) (*FileWebhookSubscriptionRepository, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open webhook subscription log: %w", err)
+	}
+
+	r := &FileWebhookSubscriptionRepository{
+		InMemoryWebhookSubscriptionRepository: NewInMemoryWebhookSubscriptionRepository(),
+		file:                                  file,
+	}
+	if err := r.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *FileWebhookSubscriptionRepository) replay() error {
+	scanner := bufio.NewScanner(r.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record webhookSubscriptionLogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("parse webhook subscription log line %d: %w", line, err)
+		}
+
+		if record.Upserted != nil {
+			r.InMemoryWebhookSubscriptionRepository.Upsert(context.Background(), record.Upserted.subscription())
+			continue
+		}
+		r.InMemoryWebhookSubscriptionRepository.Delete(context.Background(), record.Deleted)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read webhook subscription log: %w", err)
+	}
+	return nil
+}
+
+func (r *FileWebhookSubscriptionRepository) Upsert(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(webhookSubscriptionLogRecord{Upserted: newWebhookSubscriptionRecord(subscription)}); err != nil {
+		return err
+	}
+	return r.InMemoryWebhookSubscriptionRepository.Upsert(ctx, subscription)
+}
+
+func (r *FileWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.InMemoryWebhookSubscriptionRepository.GetByID(ctx, id); err != nil {
+		return err
+	}
+	if err := r.write(webhookSubscriptionLogRecord{Deleted: id}); err != nil {
+		return err
+	}
+	return r.InMemoryWebhookSubscriptionRepository.Delete(ctx, id)
+}
+
+// Ping fails once the log is closed or can no longer be read.
+func (r *FileWebhookSubscriptionRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.file.Stat(); err != nil {
+		return fmt.Errorf("stat webhook subscription log: %w", err)
+	}
+	return nil
+}
+
+// write appends the record and syncs it to disk before the in-memory state is updated.
+func (r *FileWebhookSubscriptionRepository) write(record webhookSubscriptionLogRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode webhook subscription record: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write webhook subscription log: %w", err)
+	}
+	if err := r.file.Sync(); err != nil {
+		return fmt.Errorf("sync webhook subscription log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the log to disk and closes it.
+func (r *FileWebhookSubscriptionRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Sync(); err != nil {
+		r.file.Close()
+		return fmt.Errorf("sync webhook subscription log: %w", err)
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+)
+
+// Worker is a background loop that runs until its context is canceled.
+type Worker interface {
+	Run(ctx context.Context)
+}
+
+// Server runs the HTTP server together with the background workers and shuts them down
+// in order: report draining for shutdownDelay so load balancers stop routing to it, stop
+// accepting connections and drain in-flight requests, stop the workers, then close storage.
+type Server struct {
+	httpServer      *http.Server
+	shutdownDelay   time.Duration
+	shutdownTimeout time.Duration
+	workers         []Worker
+	closers         []io.Closer
+	state           atomic.Value // entities.ServerState
+	handlers        sync.WaitGroup
+}
+
+func New(httpServer *http.Server, shutdownDelay, shutdownTimeout time.Duration) *Server {
+	s := &Server{
+		httpServer:      httpServer,
+		shutdownDelay:   shutdownDelay,
+		shutdownTimeout: shutdownTimeout,
+	}
+	s.state.Store(entities.ServerStarting)
+	return s
+}
+
+func (s *Server) State() entities.ServerState {
+	return s.state.Load().(entities.ServerState)
+}
+
+// AddWorker registers a worker to start with the server. Workers keep running while
+// requests drain, since in-flight writes may still produce work for them.
+func (s *Server) AddWorker(worker Worker) {
+	s.workers = append(s.workers, worker)
+}
+
+// AddCloser registers storage to flush and close once the workers have stopped.
+func (s *Server) AddCloser(closer io.Closer) {
+	s.closers = append(s.closers, closer)
+}
+
+// Run listens on the server's address and serves until ctx is canceled.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves on listener until ctx is canceled, then shuts down gracefully. Connections
+// still open after the shutdown timeout are cut off, but their handlers may keep writing, so
+// storage is only closed once every handler has returned.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	handler := s.httpServer.Handler
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+	s.httpServer.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handlers.Add(1)
+		defer s.handlers.Done()
+		handler.ServeHTTP(w, r)
+	})
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var wg sync.WaitGroup
+	for _, worker := range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Run(workerCtx)
+		}()
+	}
+
+	// The listener is bound, so connections made from here on are queued and then served.
+	s.state.Store(entities.ServerServing)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	var errs []error
+	select {
+	case err := <-serveErr:
+		errs = append(errs, err)
+	case <-ctx.Done():
+		s.state.Store(entities.ServerDraining)
+		if s.shutdownDelay > 0 {
+			slog.Info("shutting down, reporting not ready", slog.Duration("delay", s.shutdownDelay))
+			time.Sleep(s.shutdownDelay)
+		}
+		slog.Info("shutting down, draining requests", slog.Duration("timeout", s.shutdownTimeout))
+		if err := s.shutdown(); err != nil {
+			errs = append(errs, err)
+			slog.Warn("shutting down, waiting for handlers of cut off requests")
+		}
+		if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	s.state.Store(entities.ServerDraining)
+	s.handlers.Wait()
+	stopWorkers()
+	wg.Wait()
+
+	for _, closer := range s.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.state.Store(entities.ServerStopped)
+	return errors.Join(errs...)
+}
+
+func (s *Server) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.httpServer.Close()
+		return fmt.Errorf("drain requests: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+)
+
+// FileSink appends each event as a JSON line to a local file.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{
+		path: path,
+	}
+}
+
+func (s *FileSink) Name() string {
+	return "file:" + s.path
+}
+
+func (s *FileSink) Send(ctx context.Context, batch *dtos.OfferEventBatchDto) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open sink file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, event := range batch.Events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("write sink file: %w", err)
+		}
+	}
+	return file.Sync()
+}
@@ -0,0 +1,53 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+)
+
+// HTTPSink POSTs each batch as JSON to a URL and treats any non-2xx response as a failure.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSink) Name() string {
+	return "http:" + s.url
+}
+
+func (s *HTTPSink) Send(ctx context.Context, batch *dtos.OfferEventBatchDto) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("encode events: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("post events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post events: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
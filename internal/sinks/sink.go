@@ -0,0 +1,14 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+)
+
+// Sink delivers a batch of offer events. Delivery is at-least-once, so
+// receivers should deduplicate by event ID.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, batch *dtos.OfferEventBatchDto) error
+}
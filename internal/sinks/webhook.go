@@ -0,0 +1,27 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+// WebhookSink hands outbox events to the webhook subscriptions they match.
+type WebhookSink struct {
+	enqueueWebhookDeliveriesUseCase *use_cases.EnqueueWebhookDeliveriesUseCase
+}
+
+func NewWebhookSink(enqueueWebhookDeliveriesUseCase *use_cases.EnqueueWebhookDeliveriesUseCase) *WebhookSink {
+	return &WebhookSink{
+		enqueueWebhookDeliveriesUseCase: enqueueWebhookDeliveriesUseCase,
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhooks"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, batch *dtos.OfferEventBatchDto) error {
+	return s.enqueueWebhookDeliveriesUseCase.Execute(ctx, batch)
+}
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "eligible-offers-api"
+	tracerName  = "github.com/Drinnn/eligible-offers-api"
+)
+
+// Tracing owns the tracer provider and builds the decorators that add spans to each layer.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// New creates a tracer provider exporting to the configured OTLP/HTTP collector, or to stdout
+// as JSON when the exporter is "stdout". Close flushes spans that were not exported yet.
+func New(ctx context.Context, cfg config.TracingConfig, stdout io.Writer) (*Tracing, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	return &Tracing{
+		provider: provider,
+		tracer:   provider.Tracer(tracerName),
+	}, nil
+}
+
+func (t *Tracing) TracerProvider() trace.TracerProvider {
+	return t.provider
+}
+
+func (t *Tracing) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return t.provider.Shutdown(ctx)
+}
+
+// StartOperation starts a span named after the repository and operation, e.g. "offers.GetAll".
+// Missing records are an expected outcome and are not marked as errors.
+func (t *Tracing) StartOperation(ctx context.Context, repository, operation string) (context.Context, func(err error)) {
+	ctx, span := t.tracer.Start(ctx, repository+"."+operation, trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.String("operation", operation),
+	))
+	return ctx, func(err error) {
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartEvaluation starts a "GetEligibleOffersUseCase.evaluateOffers" span around the loop over a
+// user's candidate offers, so its time is not mistaken for the repository calls before it.
+func (t *Tracing) StartEvaluation(ctx context.Context, candidates int) (context.Context, func(evaluated int)) {
+	ctx, span := t.tracer.Start(ctx, "GetEligibleOffersUseCase.evaluateOffers", trace.WithAttributes(
+		attribute.Int("candidate_offers", candidates),
+	))
+	return ctx, func(evaluated int) {
+		span.SetAttributes(attribute.Int("evaluated_offers", evaluated))
+		span.End()
+	}
+}
+
+// GetEligibleOffers wraps an executor in a "GetEligibleOffersUseCase.Execute" span; repository
+// calls made by the use case become its children.
+func (t *Tracing) GetEligibleOffers(next use_cases.GetEligibleOffersExecutor) use_cases.GetEligibleOffersExecutor {
+	return &tracedGetEligibleOffers{next: next, tracer: t.tracer}
+}
+
+type tracedGetEligibleOffers struct {
+	next   use_cases.GetEligibleOffersExecutor
+	tracer trace.Tracer
+}
+
+func (u *tracedGetEligibleOffers) Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	ctx, span := u.tracer.Start(ctx, "GetEligibleOffersUseCase.Execute", trace.WithAttributes(
+		attribute.String("user_id", request.UserID),
+	))
+	defer span.End()
+
+	response, err := u.next.Execute(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.Int("eligible_offers", len(response.EligibleOffers)),
+		attribute.Int("in_progress_offers", len(response.InProgressOffers)),
+	)
+	return response, nil
+}
@@ -0,0 +1,119 @@
+package tracing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/config"
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/handlers"
+	"github.com/Drinnn/eligible-offers-api/internal/middlewares"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/tracing"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/go-chi/chi"
+)
+
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+}
+
+func TestTracing_EligibilityRequestSpansEachLayer(t *testing.T) {
+	output := new(bytes.Buffer)
+	appTracing, err := tracing.New(t.Context(), config.TracingConfig{Exporter: "stdout", SampleRatio: 1}, output)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Given: An eligibility route wired as in main, with materialized matches and a capped offer
+	offers := repositories.NewInMemoryOfferRepository()
+	matches := repositories.NewInMemoryOfferMatchRepository()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offer := &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, LookbackDays: 30, StartsAt: now.AddDate(0, 0, -10), EndsAt: now.AddDate(0, 0, 10), MaxRedemptions: 10}
+	offers.Upsert(t.Context(), offer)
+	matches.Add(t.Context(), offer, []*entities.Transaction{entities.NewTransaction("txn-1", "user-1", "merchant-1", "5812", 1000, now.AddDate(0, 0, -1))})
+
+	getEligibleOffersUseCase := use_cases.NewGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{
+		OfferRepository:      repositories.InstrumentOfferRepository(offers, appTracing),
+		OfferMatchReader:     repositories.InstrumentOfferMatchRepository(matches, appTracing),
+		RedemptionRepository: repositories.NewInMemoryRedemptionRepository(),
+		BudgetRepository:     repositories.InstrumentBudgetRepository(repositories.NewInMemoryBudgetRepository(), appTracing),
+		SegmentRepository:    repositories.NewInMemorySegmentRepository(),
+		UserRepository:       repositories.NewInMemoryUserRepository(),
+		ExperimentRepository: repositories.NewInMemoryExperimentRepository(),
+		EvaluationObserver:   appTracing,
+	})
+	getEligibleOffersHandler := handlers.NewGetEligibleOffersHandler(appTracing.GetEligibleOffers(getEligibleOffersUseCase))
+
+	router := chi.NewRouter()
+	router.Use(middlewares.Tracing(router, appTracing.TracerProvider()))
+	router.Get("/users/{user_id}/eligible-offers", middlewares.ErrorHandler(getEligibleOffersHandler.Handle))
+
+	// When: A request arrives with a W3C trace context
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	request := httptest.NewRequest(http.MethodGet, "/users/user-1/eligible-offers?now=2025-10-21T10:00:00Z", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	var response dtos.GetEligibleOffersResponse
+	json.NewDecoder(recorder.Body).Decode(&response)
+	if len(response.EligibleOffers) != 1 {
+		t.Fatalf("expected 1 eligible offer, got %d", len(response.EligibleOffers))
+	}
+	if err := appTracing.Close(); err != nil {
+		t.Fatalf("expected spans to flush, got %v", err)
+	}
+
+	// Then: The handler, use case and repository spans form one tree under the caller's trace
+	spans := make(map[string]exportedSpan)
+	decoder := json.NewDecoder(output)
+	for {
+		var span exportedSpan
+		if err := decoder.Decode(&span); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to decode span: %v", err)
+		}
+		if span.SpanContext.TraceID != traceID {
+			t.Errorf("expected span %s in trace %s, got %s", span.Name, traceID, span.SpanContext.TraceID)
+		}
+		spans[span.Name] = span
+	}
+
+	server, exists := spans["GET /users/{user_id}/eligible-offers"]
+	if !exists || server.Parent.SpanID != "00f067aa0ba902b7" {
+		t.Fatalf("expected a server span parented to the caller, got %+v", spans)
+	}
+	useCase := spans["GetEligibleOffersUseCase.Execute"]
+	if useCase.Parent.SpanID != server.SpanContext.SpanID {
+		t.Errorf("expected the use case span under the server span, got parent %s", useCase.Parent.SpanID)
+	}
+	for _, name := range []string{"offer_matches.GetSummaries", "offers.GetActiveAt", "GetEligibleOffersUseCase.evaluateOffers"} {
+		span, exists := spans[name]
+		if !exists || span.Parent.SpanID != useCase.SpanContext.SpanID {
+			t.Errorf("expected span %s under the use case span, got %+v", name, span)
+		}
+	}
+
+	// And: Repository calls made while evaluating offers are nested under the evaluation loop
+	evaluation := spans["GetEligibleOffersUseCase.evaluateOffers"]
+	if budget, exists := spans["budgets.GetByOfferID"]; !exists || budget.Parent.SpanID != evaluation.SpanContext.SpanID {
+		t.Errorf("expected budgets.GetByOfferID under the evaluation span, got %+v", budget)
+	}
+}
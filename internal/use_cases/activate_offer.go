@@ -0,0 +1,69 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ActivateOfferUseCase struct {
+	offerRepository          repositories.OfferRepository
+	redemptionRepository     repositories.RedemptionRepository
+	getEligibleOffersUseCase *GetEligibleOffersUseCase
+	eligibilityCache         EligibilityCacheInvalidator // optional
+}
+
+func NewActivateOfferUseCase(offerRepository repositories.OfferRepository, redemptionRepository repositories.RedemptionRepository, getEligibleOffersUseCase *GetEligibleOffersUseCase, eligibilityCache EligibilityCacheInvalidator) *ActivateOfferUseCase {
+	return &ActivateOfferUseCase{
+		offerRepository:          offerRepository,
+		redemptionRepository:     redemptionRepository,
+		getEligibleOffersUseCase: getEligibleOffersUseCase,
+		eligibilityCache:         eligibilityCache,
+	}
+}
+
+func (u *ActivateOfferUseCase) Execute(ctx context.Context, request *dtos.ActivateOfferRequest) (*dtos.ActivateOfferResponse, error) {
+	offer, err := u.offerRepository.GetByID(ctx, request.OfferID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("offer not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offer", err)
+	}
+
+	if !offer.IsLiveAt(request.Now) {
+		return nil, customErrors.NewConflictError("offer is not active")
+	}
+
+	// Activation is not an offer request, so the evaluation records no experiment exposure.
+	eligibility, err := u.getEligibleOffersUseCase.Evaluate(ctx, &dtos.GetEligibleOffersRequest{UserID: request.UserID, Now: request.Now})
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(eligibility.EligibleOffers, func(eligible dtos.EligibleOfferDto) bool {
+		return eligible.OfferID == offer.ID
+	}) {
+		return nil, customErrors.NewConflictError("user is not eligible for this offer")
+	}
+
+	activation, err := u.redemptionRepository.Activate(ctx, entities.NewActivation(request.UserID, offer.ID, request.Now))
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to activate offer", err)
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateUsers([]string{request.UserID})
+	}
+
+	return &dtos.ActivateOfferResponse{
+		UserID:      activation.UserID,
+		OfferID:     activation.OfferID,
+		Status:      string(entities.OfferStatusActivated),
+		ActivatedAt: activation.ActivatedAt,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package use_cases_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type recordingEligibilityCache struct {
+	invalidatedUsers []string
+}
+
+func (c *recordingEligibilityCache) InvalidateUsers(userIDs []string) {
+	c.invalidatedUsers = append(c.invalidatedUsers, userIDs...)
+}
+
+func (c *recordingEligibilityCache) InvalidateAll() {}
+
+func TestActivateOffer_RequiresEligibility(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	cache := &recordingEligibilityCache{}
+	getEligibleOffersUseCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{
+		OfferRepository:      offerRepo,
+		OfferMatchReader:     repositories.NewScanOfferMatchReader(txnRepo),
+		RedemptionRepository: redemptionRepo,
+	})
+	useCase := use_cases.NewActivateOfferUseCase(offerRepo, redemptionRepo, getEligibleOffersUseCase, cache)
+
+	// Given: A live offer requiring 2 transactions and a user with only 1
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 2, LookbackDays: 30, StartsAt: now.AddDate(0, 0, -10), EndsAt: now.AddDate(0, 0, 10)})
+	txnRepo.Insert(t.Context(), []*entities.Transaction{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -2)},
+	})
+
+	// When: The user activates the offer
+	_, err := useCase.Execute(t.Context(), &dtos.ActivateOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: now})
+
+	// Then: A conflict error is returned and nothing is activated
+	var conflictErr *customErrors.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if _, err := redemptionRepo.GetActivation(t.Context(), "user-1", "offer-1"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected no activation, got %v", err)
+	}
+
+	// When: The user makes a second transaction and activates again
+	txnRepo.Insert(t.Context(), []*entities.Transaction{
+		{ID: "txn-2", UserID: "user-1", MerchantID: "merchant-1", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -1)},
+	})
+	result, err := useCase.Execute(t.Context(), &dtos.ActivateOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: now})
+
+	// Then: The offer is activated and the user's cached eligibility is invalidated
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != string(entities.OfferStatusActivated) || !result.ActivatedAt.Equal(now) {
+		t.Errorf("expected an activation at %v, got %+v", now, result)
+	}
+	if _, err := redemptionRepo.GetActivation(t.Context(), "user-1", "offer-1"); err != nil {
+		t.Errorf("expected the activation to be stored, got %v", err)
+	}
+	if !slices.Equal(cache.invalidatedUsers, []string{"user-1"}) {
+		t.Errorf("expected user-1 to be invalidated, got %v", cache.invalidatedUsers)
+	}
+}
+
+func TestActivateOffer_ReturnsTypedErrors(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	getEligibleOffersUseCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{
+		OfferRepository:  offerRepo,
+		OfferMatchReader: repositories.NewScanOfferMatchReader(repositories.NewInMemoryTransactionRepository()),
+	})
+	useCase := use_cases.NewActivateOfferUseCase(offerRepo, redemptionRepo, getEligibleOffersUseCase, nil)
+
+	// Given: An offer that has already ended
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, LookbackDays: 30, StartsAt: now.AddDate(0, 0, -10), EndsAt: now.AddDate(0, 0, -1)})
+
+	// When: We activate an unknown offer
+	_, err := useCase.Execute(t.Context(), &dtos.ActivateOfferRequest{UserID: "user-1", OfferID: "missing", Now: now})
+
+	// Then: A not found error is returned
+	var notFoundErr *customErrors.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+
+	// When: We activate the ended offer
+	_, err = useCase.Execute(t.Context(), &dtos.ActivateOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: now})
+
+	// Then: A conflict error is returned
+	var conflictErr *customErrors.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("expected ConflictError, got %v", err)
+	}
+}
+
+func TestActivateOffer_ServiceErrorWrapsRepositoryCause(t *testing.T) {
+	// Given: An offer repository that fails
+	cause := errors.New("connection refused")
+	useCase := use_cases.NewActivateOfferUseCase(&failingOfferRepository{err: cause}, repositories.NewInMemoryRedemptionRepository(), nil, nil)
+
+	// When: We activate an offer
+	_, err := useCase.Execute(t.Context(), &dtos.ActivateOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: time.Now()})
+
+	// Then: The service error wraps the cause
+	var serviceErr *customErrors.ServiceError
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("expected ServiceError, got %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the error to wrap %v", cause)
+	}
+}
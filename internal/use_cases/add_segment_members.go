@@ -0,0 +1,60 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type AddSegmentMembersUseCase struct {
+	segmentRepository repositories.SegmentRepository
+	eligibilityCache  EligibilityCacheInvalidator // optional
+}
+
+func NewAddSegmentMembersUseCase(segmentRepository repositories.SegmentRepository, eligibilityCache EligibilityCacheInvalidator) *AddSegmentMembersUseCase {
+	return &AddSegmentMembersUseCase{
+		segmentRepository: segmentRepository,
+		eligibilityCache:  eligibilityCache,
+	}
+}
+
+func (u *AddSegmentMembersUseCase) Execute(ctx context.Context, request *dtos.AddSegmentMembersRequest) (*dtos.AddSegmentMembersResponse, error) {
+	var added int
+	var err error
+	if request.Replace {
+		err = u.segmentRepository.ReplaceMembers(ctx, request.SegmentID, request.UserIDs)
+	} else {
+		added, err = u.segmentRepository.AddMembers(ctx, request.SegmentID, request.UserIDs)
+	}
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to add segment members", err)
+	}
+
+	if u.eligibilityCache != nil {
+		if request.Replace {
+			u.eligibilityCache.InvalidateAll()
+		} else {
+			u.eligibilityCache.InvalidateUsers(request.UserIDs)
+		}
+	}
+
+	memberCount, err := u.segmentRepository.CountMembers(ctx, request.SegmentID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to count segment members", err)
+	}
+	if request.Replace {
+		added = memberCount
+	}
+
+	return &dtos.AddSegmentMembersResponse{
+		SegmentID:   request.SegmentID,
+		Added:       added,
+		MemberCount: memberCount,
+	}, nil
+}
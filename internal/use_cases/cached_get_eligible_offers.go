@@ -0,0 +1,165 @@
+package use_cases
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/cache"
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+)
+
+// GetEligibleOffersExecutor is implemented by GetEligibleOffersUseCase and its decorators.
+type GetEligibleOffersExecutor interface {
+	Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error)
+}
+
+// EligibilityCacheInvalidator is notified when data that eligibility is computed from changes.
+type EligibilityCacheInvalidator interface {
+	InvalidateUsers(userIDs []string)
+	InvalidateAll()
+}
+
+type eligibilityCacheKey struct {
+	userID string
+	bucket int64 // start of the time bucket, unix seconds
+}
+
+type eligibilityCacheEntry struct {
+	response       *dtos.GetEligibleOffersResponse
+	userGeneration uint64
+	generation     uint64
+}
+
+// eligibilityCacheUser is tracked only while the user has cached entries or computations in
+// flight, since no other reader can hold one of its generations.
+type eligibilityCacheUser struct {
+	generation uint64
+	entries    int
+	computing  int
+}
+
+type EligibilityCacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	Users     int    `json:"users"` // users with cached entries or computations in flight
+}
+
+// CachedGetEligibleOffersUseCase is a read-through cache in front of another executor. Entries
+// are keyed by user and the time bucket containing request.Now, and hold the response computed
+// for the first request in that bucket.
+type CachedGetEligibleOffersUseCase struct {
+	next       GetEligibleOffersExecutor
+	bucketSize time.Duration
+
+	mu         sync.Mutex
+	entries    *cache.LRU[eligibilityCacheKey, *eligibilityCacheEntry]
+	generation uint64
+	users      map[string]*eligibilityCacheUser
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func NewCachedGetEligibleOffersUseCase(next GetEligibleOffersExecutor, bucketSize time.Duration, capacity int) *CachedGetEligibleOffersUseCase {
+	return &CachedGetEligibleOffersUseCase{
+		next:       next,
+		bucketSize: bucketSize,
+		entries:    cache.NewLRU[eligibilityCacheKey, *eligibilityCacheEntry](capacity),
+		users:      make(map[string]*eligibilityCacheUser),
+	}
+}
+
+func (u *CachedGetEligibleOffersUseCase) Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	key := eligibilityCacheKey{userID: request.UserID, bucket: request.Now.Truncate(u.bucketSize).Unix()}
+
+	u.mu.Lock()
+	entry, exists := u.entries.Get(key)
+	user := u.users[request.UserID]
+	if user == nil {
+		user = &eligibilityCacheUser{}
+		u.users[request.UserID] = user
+	}
+	userGeneration, generation := user.generation, u.generation
+	if exists && entry.userGeneration == userGeneration && entry.generation == generation {
+		u.mu.Unlock()
+		u.hits.Add(1)
+		return entry.response, nil
+	}
+	user.computing++
+	u.mu.Unlock()
+	u.misses.Add(1)
+
+	response, err := u.next.Execute(ctx, request)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user.computing--
+	if err != nil {
+		u.release(request.UserID)
+		return nil, err
+	}
+
+	// Entries are tagged with the generations read before computing, so a result that
+	// raced with an invalidation is treated as stale on the next read.
+	if _, replaced := u.entries.Get(key); !replaced {
+		user.entries++
+	}
+	if evicted, ok := u.entries.Add(key, &eligibilityCacheEntry{response: response, userGeneration: userGeneration, generation: generation}); ok {
+		u.evictions.Add(1)
+		u.users[evicted.userID].entries--
+		u.release(evicted.userID)
+	}
+
+	return response, nil
+}
+
+// release stops tracking a user once nothing refers to its generation.
+func (u *CachedGetEligibleOffersUseCase) release(userID string) {
+	if user := u.users[userID]; user.entries == 0 && user.computing == 0 {
+		delete(u.users, userID)
+	}
+}
+
+func (u *CachedGetEligibleOffersUseCase) InvalidateUsers(userIDs []string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// Users that are not tracked have nothing cached or being computed to invalidate.
+	for _, userID := range userIDs {
+		if user := u.users[userID]; user != nil {
+			user.generation++
+		}
+	}
+}
+
+func (u *CachedGetEligibleOffersUseCase) InvalidateAll() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.generation++
+	u.entries.Purge()
+	for userID, user := range u.users {
+		user.entries = 0
+		u.release(userID)
+	}
+}
+
+func (u *CachedGetEligibleOffersUseCase) Stats() EligibilityCacheStats {
+	u.mu.Lock()
+	size, users := u.entries.Len(), len(u.users)
+	u.mu.Unlock()
+
+	return EligibilityCacheStats{
+		Hits:      u.hits.Load(),
+		Misses:    u.misses.Load(),
+		Evictions: u.evictions.Load(),
+		Size:      size,
+		Users:     users,
+	}
+}
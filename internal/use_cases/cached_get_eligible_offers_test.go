@@ -0,0 +1,122 @@
+package use_cases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type countingGetEligibleOffersExecutor struct {
+	calls map[string]int
+}
+
+func (e *countingGetEligibleOffersExecutor) Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	e.calls[request.UserID]++
+	return &dtos.GetEligibleOffersResponse{UserID: request.UserID}, nil
+}
+
+func TestCachedGetEligibleOffers_ReadThroughAndInvalidation(t *testing.T) {
+	next := &countingGetEligibleOffersExecutor{calls: make(map[string]int)}
+	useCase := use_cases.NewCachedGetEligibleOffersUseCase(next, time.Minute, 2)
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	execute := func(userID string, at time.Time) {
+		t.Helper()
+		if _, err := useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: at}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// Given: Two reads for the same user inside one time bucket
+	execute("user-1", now)
+	execute("user-1", now.Add(30*time.Second))
+
+	// Then: Only the first one is computed
+	if next.calls["user-1"] != 1 {
+		t.Errorf("expected 1 computation, got %d", next.calls["user-1"])
+	}
+
+	// When: The user is read in the next bucket
+	execute("user-1", now.Add(time.Minute))
+
+	// Then: It is computed again
+	if next.calls["user-1"] != 2 {
+		t.Errorf("expected 2 computations after bucket change, got %d", next.calls["user-1"])
+	}
+
+	// When: Another user is cached and the first user is invalidated
+	execute("user-2", now)
+	useCase.InvalidateUsers([]string{"user-1"})
+	execute("user-1", now.Add(time.Minute))
+	execute("user-2", now)
+
+	// Then: Only the invalidated user is recomputed
+	if next.calls["user-1"] != 3 || next.calls["user-2"] != 1 {
+		t.Errorf("expected 3 and 1 computations, got %d and %d", next.calls["user-1"], next.calls["user-2"])
+	}
+
+	// When: Everything is invalidated
+	useCase.InvalidateAll()
+	execute("user-2", now)
+
+	// Then: Every user is recomputed
+	if next.calls["user-2"] != 2 {
+		t.Errorf("expected 2 computations after invalidate all, got %d", next.calls["user-2"])
+	}
+
+	// When: More keys than the capacity are cached
+	execute("user-3", now)
+	execute("user-4", now)
+	execute("user-2", now)
+
+	// Then: The least recently used entry was evicted
+	if next.calls["user-2"] != 3 {
+		t.Errorf("expected user-2 to be evicted and recomputed, got %d computations", next.calls["user-2"])
+	}
+
+	stats := useCase.Stats()
+	if stats.Hits != 2 || stats.Misses != 8 || stats.Evictions != 3 || stats.Size != 2 || stats.Users != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCachedGetEligibleOffers_TracksOnlyCachedUsers(t *testing.T) {
+	next := &countingGetEligibleOffersExecutor{calls: make(map[string]int)}
+	useCase := use_cases.NewCachedGetEligibleOffersUseCase(next, time.Minute, 10)
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	// Given: Many more users read and invalidated than the cache holds
+	for i := range 1000 {
+		userID := fmt.Sprintf("user-%d", i)
+		useCase.InvalidateUsers([]string{userID})
+		if _, err := useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		useCase.InvalidateUsers([]string{userID})
+	}
+
+	// Then: Only the users still cached are tracked
+	if stats := useCase.Stats(); stats.Size != 10 || stats.Users != 10 {
+		t.Errorf("expected 10 entries for 10 users, got %+v", stats)
+	}
+
+	// When: Everything is invalidated
+	useCase.InvalidateAll()
+
+	// Then: No user is tracked anymore
+	if stats := useCase.Stats(); stats.Size != 0 || stats.Users != 0 {
+		t.Errorf("expected an empty cache, got %+v", stats)
+	}
+
+	// And: An invalidated user that is read again is recomputed once, then cached
+	for range 2 {
+		useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: "user-1", Now: now})
+	}
+	if next.calls["user-1"] != 2 {
+		t.Errorf("expected 2 computations for user-1, got %d", next.calls["user-1"])
+	}
+}
@@ -0,0 +1,68 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+// ServerStateReader reports where the server is in its lifecycle.
+type ServerStateReader interface {
+	State() entities.ServerState
+}
+
+type CheckReadinessUseCase struct {
+	server                    ServerStateReader
+	components                map[string]repositories.Pinger
+	outboxRepository          repositories.OutboxRepository
+	webhookDeliveryRepository repositories.WebhookDeliveryRepository // optional
+}
+
+func NewCheckReadinessUseCase(server ServerStateReader, components map[string]repositories.Pinger, outboxRepository repositories.OutboxRepository, webhookDeliveryRepository repositories.WebhookDeliveryRepository) *CheckReadinessUseCase {
+	return &CheckReadinessUseCase{
+		server:                    server,
+		components:                components,
+		outboxRepository:          outboxRepository,
+		webhookDeliveryRepository: webhookDeliveryRepository,
+	}
+}
+
+// Execute pings every component and reports the depth of the background job queues. The
+// server is ready only while serving with every component up; queue depths are informational.
+func (u *CheckReadinessUseCase) Execute(ctx context.Context) (*dtos.CheckReadinessResponse, error) {
+	state := u.server.State()
+	response := &dtos.CheckReadinessResponse{
+		Ready:      state == entities.ServerServing,
+		State:      string(state),
+		Components: make(map[string]dtos.ComponentStatusDto, len(u.components)),
+		Queues:     make(map[string]dtos.QueueStatusDto),
+	}
+
+	for name, component := range u.components {
+		if err := component.Ping(ctx); err != nil {
+			response.Ready = false
+			response.Components[name] = dtos.ComponentStatusDto{Status: "down", Error: err.Error()}
+			continue
+		}
+		response.Components[name] = dtos.ComponentStatusDto{Status: "up"}
+	}
+
+	pendingEvents, err := u.outboxRepository.CountPending(ctx)
+	response.Queues["outbox"] = queueStatus(pendingEvents, err)
+
+	if u.webhookDeliveryRepository != nil {
+		pendingDeliveries, err := u.webhookDeliveryRepository.CountPending(ctx)
+		response.Queues["webhook_deliveries"] = queueStatus(pendingDeliveries, err)
+	}
+
+	return response, nil
+}
+
+func queueStatus(depth int, err error) dtos.QueueStatusDto {
+	if err != nil {
+		return dtos.QueueStatusDto{Error: err.Error()}
+	}
+	return dtos.QueueStatusDto{Depth: depth}
+}
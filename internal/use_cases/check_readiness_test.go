@@ -0,0 +1,83 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type fixedServerState entities.ServerState
+
+func (s fixedServerState) State() entities.ServerState {
+	return entities.ServerState(s)
+}
+
+type failingPinger struct{}
+
+func (failingPinger) Ping(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestCheckReadiness_ReportsComponentsAndQueues(t *testing.T) {
+	outboxRepo := repositories.NewInMemoryOutboxRepository()
+	deliveryRepo := repositories.NewInMemoryWebhookDeliveryRepository()
+	offerRepo := repositories.NewInMemoryOfferRepository()
+
+	// Given: Two pending events and one webhook delivery still pending out of three
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	outboxRepo.Append(t.Context(), []*entities.OfferEvent{{ID: "event-1"}, {ID: "event-2"}})
+	deliveryRepo.Insert(t.Context(), []*entities.WebhookDelivery{
+		{ID: "delivery-1", SubscriptionID: "sub-1", EventID: "event-1", Status: entities.WebhookDeliveryPending, NextAttemptAt: now},
+		{ID: "delivery-2", SubscriptionID: "sub-1", EventID: "event-2", Status: entities.WebhookDeliveryPending, NextAttemptAt: now},
+		{ID: "delivery-3", SubscriptionID: "sub-1", EventID: "event-3", Status: entities.WebhookDeliveryDeadLettered, NextAttemptAt: now},
+	})
+	deliveryRepo.Update(t.Context(), &entities.WebhookDelivery{ID: "delivery-2", SubscriptionID: "sub-1", EventID: "event-2", Status: entities.WebhookDeliveryDelivered, DeliveredAt: &now})
+
+	// When: A serving server with healthy components is checked
+	state := fixedServerState(entities.ServerServing)
+	components := map[string]repositories.Pinger{"offers": offerRepo, "outbox": outboxRepo}
+	result, err := use_cases.NewCheckReadinessUseCase(state, components, outboxRepo, deliveryRepo).Execute(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: It is ready and reports queue depths
+	if !result.Ready || result.State != "serving" {
+		t.Errorf("expected ready while serving, got ready=%v state=%s", result.Ready, result.State)
+	}
+	if result.Components["offers"].Status != "up" || result.Components["outbox"].Status != "up" {
+		t.Errorf("expected every component up, got %+v", result.Components)
+	}
+	if result.Queues["outbox"].Depth != 2 || result.Queues["webhook_deliveries"].Depth != 1 {
+		t.Errorf("expected queue depths 2 and 1, got %+v", result.Queues)
+	}
+
+	// When: A component is down
+	components["segments"] = failingPinger{}
+	result, _ = use_cases.NewCheckReadinessUseCase(state, components, outboxRepo, deliveryRepo).Execute(t.Context())
+
+	// Then: It is not ready and the failing component is reported
+	if result.Ready {
+		t.Error("expected not ready with a component down")
+	}
+	if status := result.Components["segments"]; status.Status != "down" || status.Error != "connection refused" {
+		t.Errorf("expected segments down with its error, got %+v", status)
+	}
+
+	// When: The server is draining
+	delete(components, "segments")
+	result, _ = use_cases.NewCheckReadinessUseCase(fixedServerState(entities.ServerDraining), components, outboxRepo, nil).Execute(t.Context())
+
+	// Then: It is not ready, and the webhook queue is omitted when webhooks are off
+	if result.Ready || result.State != "draining" {
+		t.Errorf("expected not ready while draining, got ready=%v state=%s", result.Ready, result.State)
+	}
+	if _, exists := result.Queues["webhook_deliveries"]; exists {
+		t.Errorf("expected no webhook queue, got %+v", result.Queues)
+	}
+}
@@ -0,0 +1,53 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type DeleteSegmentUseCase struct {
+	segmentRepository repositories.SegmentRepository
+	offerRepository   repositories.OfferRepository
+	segmentLock       *sync.RWMutex // shared with UpsertOfferUseCase
+}
+
+func NewDeleteSegmentUseCase(segmentRepository repositories.SegmentRepository, offerRepository repositories.OfferRepository, segmentLock *sync.RWMutex) *DeleteSegmentUseCase {
+	return &DeleteSegmentUseCase{
+		segmentRepository: segmentRepository,
+		offerRepository:   offerRepository,
+		segmentLock:       segmentLock,
+	}
+}
+
+func (u *DeleteSegmentUseCase) Execute(ctx context.Context, request *dtos.DeleteSegmentRequest) error {
+	// Offer upserts hold the read lock while checking and saving segment references, so no
+	// offer can start referencing the segment between this check and the delete.
+	u.segmentLock.Lock()
+	defer u.segmentLock.Unlock()
+
+	offers, err := u.offerRepository.GetAll(ctx)
+	if err != nil {
+		return customErrors.NewServiceError("failed to get offers", err)
+	}
+	for _, offer := range offers {
+		if slices.Contains(offer.IncludeSegments, request.SegmentID) || slices.Contains(offer.ExcludeSegments, request.SegmentID) {
+			return customErrors.NewConflictError("segment is referenced by offer " + offer.ID)
+		}
+	}
+
+	err = u.segmentRepository.Delete(ctx, request.SegmentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return customErrors.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		return customErrors.NewServiceError("failed to delete segment", err)
+	}
+
+	return nil
+}
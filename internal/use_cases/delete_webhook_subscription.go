@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type DeleteWebhookSubscriptionUseCase struct {
+	subscriptionRepository repositories.WebhookSubscriptionRepository
+}
+
+func NewDeleteWebhookSubscriptionUseCase(subscriptionRepository repositories.WebhookSubscriptionRepository) *DeleteWebhookSubscriptionUseCase {
+	return &DeleteWebhookSubscriptionUseCase{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+func (u *DeleteWebhookSubscriptionUseCase) Execute(ctx context.Context, request *dtos.DeleteWebhookSubscriptionRequest) error {
+	err := u.subscriptionRepository.Delete(ctx, request.SubscriptionID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return customErrors.NewNotFoundError("webhook subscription not found")
+	}
+	if err != nil {
+		return customErrors.NewServiceError("failed to delete webhook subscription", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type EnqueueWebhookDeliveriesUseCase struct {
+	offerRepository        repositories.OfferRepository
+	subscriptionRepository repositories.WebhookSubscriptionRepository
+	deliveryRepository     repositories.WebhookDeliveryRepository
+}
+
+func NewEnqueueWebhookDeliveriesUseCase(offerRepository repositories.OfferRepository, subscriptionRepository repositories.WebhookSubscriptionRepository, deliveryRepository repositories.WebhookDeliveryRepository) *EnqueueWebhookDeliveriesUseCase {
+	return &EnqueueWebhookDeliveriesUseCase{
+		offerRepository:        offerRepository,
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+	}
+}
+
+// Execute fans each event out to the subscriptions of the offer's merchant. Deliveries are
+// attempted later by the webhook dispatcher, so slow subscribers never hold up the outbox.
+func (u *EnqueueWebhookDeliveriesUseCase) Execute(ctx context.Context, batch *dtos.OfferEventBatchDto) error {
+	now := time.Now()
+	deliveries := make([]*entities.WebhookDelivery, 0)
+
+	for _, eventDto := range batch.Events {
+		offer, err := u.offerRepository.GetByID(ctx, eventDto.OfferID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return customErrors.NewServiceError("failed to get offer", err)
+		}
+
+		subscriptions, err := u.subscriptionRepository.GetByMerchantID(ctx, offer.MerchantID)
+		if err != nil {
+			return customErrors.NewServiceError("failed to get webhook subscriptions", err)
+		}
+
+		event := &entities.OfferEvent{
+			ID:         eventDto.ID,
+			Type:       entities.OfferEventType(eventDto.Type),
+			UserID:     eventDto.UserID,
+			OfferID:    eventDto.OfferID,
+			OccurredAt: eventDto.OccurredAt,
+		}
+		payload, err := json.Marshal(dtos.WebhookPayloadDto{
+			ID:         event.ID,
+			Type:       string(event.Type),
+			MerchantID: offer.MerchantID,
+			OfferID:    event.OfferID,
+			UserID:     event.UserID,
+			OccurredAt: event.OccurredAt,
+		})
+		if err != nil {
+			return customErrors.NewServiceError("failed to encode webhook payload", err)
+		}
+
+		for _, subscription := range subscriptions {
+			if subscription.Accepts(event.Type) {
+				deliveries = append(deliveries, entities.NewWebhookDelivery(subscription.ID, event, payload, now))
+			}
+		}
+	}
+
+	if err := u.deliveryRepository.Insert(ctx, deliveries); err != nil {
+		return customErrors.NewServiceError("failed to enqueue webhook deliveries", err)
+	}
+
+	return nil
+}
@@ -1,8 +1,11 @@
 package use_cases
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/Drinnn/eligible-offers-api/internal/dtos"
@@ -12,70 +15,253 @@ import (
 )
 
 type GetEligibleOffersUseCase struct {
-	offerRepository       repositories.OfferRepository
-	transactionRepository repositories.TransactionRepository
+	offerRepository      repositories.OfferRepository
+	offerMatchReader     repositories.OfferMatchReader
+	redemptionRepository repositories.RedemptionRepository
+	budgetRepository     repositories.BudgetRepository
+	segmentRepository    repositories.SegmentRepository
+	userRepository       repositories.UserRepository
+	experimentRepository repositories.ExperimentRepository
+	evaluationObserver   EvaluationObserver // optional
 }
 
-func NewGetEligibleOffersUseCase(offerRepository repositories.OfferRepository, transactionRepository repositories.TransactionRepository) *GetEligibleOffersUseCase {
+// EvaluationObserver is told when the loop evaluating a user's candidate offers starts, and
+// returns the context to run it with and a function to call with the number of offers evaluated.
+type EvaluationObserver interface {
+	StartEvaluation(ctx context.Context, candidates int) (context.Context, func(evaluated int))
+}
+
+// GetEligibleOffersDependencies groups the repositories eligibility is evaluated against.
+type GetEligibleOffersDependencies struct {
+	OfferRepository      repositories.OfferRepository
+	OfferMatchReader     repositories.OfferMatchReader
+	RedemptionRepository repositories.RedemptionRepository
+	BudgetRepository     repositories.BudgetRepository
+	SegmentRepository    repositories.SegmentRepository
+	UserRepository       repositories.UserRepository
+	ExperimentRepository repositories.ExperimentRepository
+	EvaluationObserver   EvaluationObserver // optional
+}
+
+func NewGetEligibleOffersUseCase(deps GetEligibleOffersDependencies) *GetEligibleOffersUseCase {
 	return &GetEligibleOffersUseCase{
-		offerRepository:       offerRepository,
-		transactionRepository: transactionRepository,
+		offerRepository:      deps.OfferRepository,
+		offerMatchReader:     deps.OfferMatchReader,
+		redemptionRepository: deps.RedemptionRepository,
+		budgetRepository:     deps.BudgetRepository,
+		segmentRepository:    deps.SegmentRepository,
+		userRepository:       deps.UserRepository,
+		experimentRepository: deps.ExperimentRepository,
+		evaluationObserver:   deps.EvaluationObserver,
 	}
 }
 
-func (u *GetEligibleOffersUseCase) Execute(request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
-	offers, err := u.offerRepository.GetAll()
+// Execute serves a user's offers and records the experiment exposures of the eligible ones.
+func (u *GetEligibleOffersUseCase) Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	return u.evaluate(ctx, request, true)
+}
+
+// Evaluate computes the same offers as Execute without recording exposures, for callers
+// evaluating users who did not ask for their offers.
+func (u *GetEligibleOffersUseCase) Evaluate(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	return u.evaluate(ctx, request, false)
+}
+
+func (u *GetEligibleOffersUseCase) evaluate(ctx context.Context, request *dtos.GetEligibleOffersRequest, recordExposure bool) (*dtos.GetEligibleOffersResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	liveOffers, err := u.offerRepository.GetActiveAt(ctx, request.Now)
 	if err != nil {
-		return nil, customErrors.NewServiceError("failed to get active offers")
+		return nil, customErrors.NewServiceError("failed to get active offers", err)
 	}
+	// The repository only applies the date range, schedules are checked here.
+	activeOffers := u.filterActiveOffers(liveOffers, request.Now)
 
-	userTransactions, err := u.transactionRepository.GetByUserID(request.UserID)
+	// Offers without a matching transaction can be neither eligible nor in progress.
+	summaries, err := u.offerMatchReader.GetSummaries(ctx, request.UserID, activeOffers, request.Now)
 	if err != nil {
-		return nil, customErrors.NewServiceError("failed to get user transactions")
+		return nil, customErrors.NewServiceError("failed to get offer matches", err)
 	}
+	offers := make([]*entities.Offer, 0, len(summaries))
+	for _, offer := range activeOffers {
+		if _, exists := summaries[offer.ID]; exists {
+			offers = append(offers, offer)
+		}
+	}
+	slices.SortFunc(offers, func(a, b *entities.Offer) int {
+		return strings.Compare(a.ID, b.ID)
+	})
 
-	activeOffers := u.filterActiveOffers(offers, request.Now)
-	eligibleOffers := make([]*entities.Offer, 0)
+	segmentIDs, err := u.segmentRepository.GetSegmentIDsByUserID(ctx, request.UserID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get user segments", err)
+	}
+	userSegments := make(map[string]bool, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		userSegments[segmentID] = true
+	}
 
-	for _, offer := range activeOffers {
-		lookbackStart := request.Now.AddDate(0, 0, -offer.LookbackDays)
-		count := 0
+	user, err := u.userRepository.GetByID(ctx, request.UserID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewServiceError("failed to get user profile", err)
+	}
 
-		for _, transaction := range userTransactions {
-			if transaction.ApprovedAt.Before(lookbackStart) || transaction.ApprovedAt.After(request.Now) {
-				continue
-			}
+	activations, err := u.redemptionRepository.GetActivationsByUserID(ctx, request.UserID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get user activations", err)
+	}
+	activated := make(map[string]bool, len(activations))
+	for _, activation := range activations {
+		activated[activation.OfferID] = true
+	}
+
+	redemptions, err := u.redemptionRepository.GetRedemptionsByUserID(ctx, request.UserID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get user redemptions", err)
+	}
+	redeemed := make(map[string]bool, len(redemptions))
+	for _, redemption := range redemptions {
+		redeemed[redemption.OfferID] = true
+	}
 
-			if transaction.MerchantID == offer.MerchantID || slices.Contains(offer.MCCWhitelist, transaction.MCC) {
-				count++
+	eligibleOffersDtos := make([]dtos.EligibleOfferDto, 0)
+	inProgressOffersDtos := make([]dtos.EligibleOfferDto, 0)
+	offersEvaluated := 0
+
+	if u.evaluationObserver != nil {
+		var finish func(evaluated int)
+		ctx, finish = u.evaluationObserver.StartEvaluation(ctx, len(offers))
+		defer func() { finish(offersEvaluated) }()
+	}
+
+	for _, offer := range offers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !offer.TargetsUser(userSegments) || !offer.MatchesProfile(user, request.Now) {
+			continue
+		}
+
+		if offer.SingleUse && redeemed[offer.ID] {
+			continue
+		}
+
+		if offer.MaxRedemptions > 0 || offer.BudgetCents > 0 {
+			usage, err := u.budgetRepository.GetByOfferID(ctx, offer.ID)
+			if err != nil {
+				return nil, customErrors.NewServiceError("failed to get offer budget", err)
+			}
+			if offer.IsExhausted(usage) {
+				continue
 			}
 		}
 
-		if count >= offer.MinTxnCount {
-			eligibleOffers = append(eligibleOffers, offer)
+		evaluation, eligible := u.evaluateOffer(offer, summaries[offer.ID], request.Now)
+		offersEvaluated++
+
+		status := entities.OfferStatusInProgress
+		if eligible {
+			status = entities.OfferStatusEligible
 		}
-	}
+		if activated[offer.ID] {
+			status = entities.OfferStatusActivated
+		}
+		if redeemed[offer.ID] {
+			status = entities.OfferStatusRedeemed
+		}
+		evaluation.Status = string(status)
 
-	eligibleOffersDtos := make([]dtos.EligibleOfferDto, 0)
-	for _, offer := range eligibleOffers {
-		eligibleOffersDtos = append(eligibleOffersDtos, dtos.EligibleOfferDto{
-			OfferID: offer.ID,
-			Reason:  fmt.Sprintf(">= %d transactions in last %d days", offer.MinTxnCount, offer.LookbackDays),
-		})
+		if eligible {
+			suppressed, err := u.applyExperiment(ctx, offer, request.UserID, request.Now, recordExposure)
+			if err != nil {
+				return nil, err
+			}
+			if suppressed {
+				continue
+			}
+			eligibleOffersDtos = append(eligibleOffersDtos, evaluation)
+		} else if evaluation.Progress.TransactionCount.Current > 0 {
+			inProgressOffersDtos = append(inProgressOffersDtos, evaluation)
+		}
 	}
 
 	return &dtos.GetEligibleOffersResponse{
-		UserID:         request.UserID,
-		EligibleOffers: eligibleOffersDtos,
+		UserID:           request.UserID,
+		EligibleOffers:   eligibleOffersDtos,
+		InProgressOffers: inProgressOffersDtos,
 	}, nil
 }
 
+// evaluateOffer turns the summary of the offer's matching transactions into its progress.
+func (u *GetEligibleOffersUseCase) evaluateOffer(offer *entities.Offer, summary repositories.OfferMatchSummary, now time.Time) (dtos.EligibleOfferDto, bool) {
+	window := offer.LookbackWindow(now)
+	progress := dtos.ProgressDto{
+		TransactionCount: dtos.ProgressValueDto{Current: summary.TransactionCount, Required: offer.MinTxnCount},
+	}
+	reasons := []string{fmt.Sprintf(">= %d transactions %s", offer.MinTxnCount, offer.WindowDescription())}
+	eligible := progress.TransactionCount.Met()
+
+	if offer.MinDistinctMerchants > 0 {
+		progress.DistinctMerchants = &dtos.ProgressValueDto{Current: summary.DistinctMerchants, Required: offer.MinDistinctMerchants}
+		reasons = append(reasons, fmt.Sprintf(">= %d distinct merchants", offer.MinDistinctMerchants))
+		eligible = eligible && progress.DistinctMerchants.Met()
+	}
+
+	if offer.Streak != nil {
+		progress.Streak = &dtos.ProgressValueDto{Current: summary.Streak, Required: offer.Streak.Count}
+		reasons = append(reasons, fmt.Sprintf("a transaction every %s for %d consecutive %ss", offer.Streak.Period, offer.Streak.Count, offer.Streak.Period))
+		eligible = eligible && progress.Streak.Met()
+	}
+
+	return dtos.EligibleOfferDto{
+		OfferID:     offer.ID,
+		Reason:      strings.Join(reasons, ", "),
+		WindowStart: window.Start,
+		WindowEnd:   window.End,
+		Progress:    progress,
+	}, eligible
+}
+
+// applyExperiment reports whether the offer must be hidden from an eligible user because they
+// fell into the control group of its experiment, and logs their exposure when asked to.
+func (u *GetEligibleOffersUseCase) applyExperiment(ctx context.Context, offer *entities.Offer, userID string, now time.Time, recordExposure bool) (bool, error) {
+	experiment, err := u.experimentRepository.GetByOfferID(ctx, offer.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, customErrors.NewServiceError("failed to get offer experiment", err)
+	}
+
+	variant := experiment.Assign(userID)
+	if variant == entities.VariantNone {
+		return false, nil
+	}
+	if !recordExposure {
+		return variant == entities.VariantControl, nil
+	}
+
+	exposure := &entities.ExperimentExposure{
+		ExperimentID: experiment.ID,
+		UserID:       userID,
+		Variant:      variant,
+		Suppressed:   variant == entities.VariantControl,
+		ExposedAt:    now,
+	}
+	if err := u.experimentRepository.RecordExposure(ctx, exposure); err != nil {
+		return false, customErrors.NewServiceError("failed to record experiment exposure", err)
+	}
+
+	return exposure.Suppressed, nil
+}
+
 func (u *GetEligibleOffersUseCase) filterActiveOffers(offers []*entities.Offer, now time.Time) []*entities.Offer {
 	active := make([]*entities.Offer, 0)
 	for _, offer := range offers {
-		if offer.Active &&
-			!now.Before(offer.StartsAt) &&
-			!now.After(offer.EndsAt) {
+		if offer.IsLiveAt(now) {
 			active = append(active, offer)
 		}
 	}
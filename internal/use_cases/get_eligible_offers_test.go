@@ -1,6 +1,7 @@
 package use_cases_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,289 +11,878 @@ import (
 	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
 )
 
-func TestGetEligibleOffers_UserQualifies(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An active offer with min txn count of 3 in last 30 days
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		Active:       true,
-		MinTxnCount:  3,
-		LookbackDays: 30,
-		StartsAt:     now.AddDate(0, 0, -10), // 10 days ago
-		EndsAt:       now.AddDate(0, 0, 10),  // 10 days in the future
-	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with 3 transactions in the last 30 days
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -5)},
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -10)},
-		{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -15)},
-	}
-	txnRepo.Insert(transactions)
-
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
+// newGetEligibleOffersUseCase fills the repositories a test leaves unset with empty in-memory ones.
+func newGetEligibleOffersUseCase(deps use_cases.GetEligibleOffersDependencies) *use_cases.GetEligibleOffersUseCase {
+	if deps.RedemptionRepository == nil {
+		deps.RedemptionRepository = repositories.NewInMemoryRedemptionRepository()
 	}
-	result, err := useCase.Execute(request)
-
-	// Then: User must be eligible for the offer
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	if deps.BudgetRepository == nil {
+		deps.BudgetRepository = repositories.NewInMemoryBudgetRepository()
 	}
-	if len(result.EligibleOffers) != 1 {
-		t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+	if deps.SegmentRepository == nil {
+		deps.SegmentRepository = repositories.NewInMemorySegmentRepository()
 	}
-	if result.EligibleOffers[0].OfferID != "offer-1" {
-		t.Errorf("expected offer-1, got %s", result.EligibleOffers[0].OfferID)
+	if deps.UserRepository == nil {
+		deps.UserRepository = repositories.NewInMemoryUserRepository()
 	}
-	expectedReason := ">= 3 transactions in last 30 days"
-	if result.EligibleOffers[0].Reason != expectedReason {
-		t.Errorf("expected reason '%s', got '%s'", expectedReason, result.EligibleOffers[0].Reason)
+	if deps.ExperimentRepository == nil {
+		deps.ExperimentRepository = repositories.NewInMemoryExperimentRepository()
 	}
+	return use_cases.NewGetEligibleOffersUseCase(deps)
 }
 
-func TestGetEligibleOffers_NotEnoughTransactions(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An active offer requiring 3 transactions
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		Active:       true,
-		MinTxnCount:  3,
-		LookbackDays: 30,
-		StartsAt:     now.AddDate(0, 0, -10),
-		EndsAt:       now.AddDate(0, 0, 10),
-	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with only 2 transactions (not enough!)
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -5)},
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -10)},
-	}
-	txnRepo.Insert(transactions)
+// matchReaders are the ways the eligibility tests read offer matches: scanning the user's
+// transactions on every request, or from the materialized store the app keeps up to date.
+var matchReaders = []struct {
+	name         string
+	materialized bool
+}{
+	{name: "scan", materialized: false},
+	{name: "materialized", materialized: true},
+}
 
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
-	}
-	result, err := useCase.Execute(request)
+// eligibilityFixture is a GetEligibleOffersUseCase over empty in-memory repositories.
+// With the materialized store, writing offers and transactions through the fixture records
+// their matches the way the offer and ingestion use cases do.
+type eligibilityFixture struct {
+	useCase      *use_cases.GetEligibleOffersUseCase
+	offers       *repositories.InMemoryOfferRepository
+	transactions *repositories.InMemoryTransactionRepository
+	matches      *repositories.InMemoryOfferMatchRepository // nil when scanning
+	segments     *repositories.InMemorySegmentRepository
+	users        *repositories.InMemoryUserRepository
+	experiments  *repositories.InMemoryExperimentRepository
+}
 
-	// Then: User must NOT be eligible
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if len(result.EligibleOffers) != 0 {
-		t.Errorf("expected 0 eligible offers, got %d", len(result.EligibleOffers))
+// runWithMatchReaders runs the test once per match reader, each with a fresh fixture.
+func runWithMatchReaders(t *testing.T, test func(t *testing.T, f *eligibilityFixture)) {
+	for _, tt := range matchReaders {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &eligibilityFixture{
+				offers:       repositories.NewInMemoryOfferRepository(),
+				transactions: repositories.NewInMemoryTransactionRepository(),
+				segments:     repositories.NewInMemorySegmentRepository(),
+				users:        repositories.NewInMemoryUserRepository(),
+				experiments:  repositories.NewInMemoryExperimentRepository(),
+			}
+
+			var matchReader repositories.OfferMatchReader = repositories.NewScanOfferMatchReader(f.transactions)
+			if tt.materialized {
+				f.matches = repositories.NewInMemoryOfferMatchRepository()
+				matchReader = f.matches
+			}
+
+			f.useCase = newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{
+				OfferRepository:      f.offers,
+				OfferMatchReader:     matchReader,
+				SegmentRepository:    f.segments,
+				UserRepository:       f.users,
+				ExperimentRepository: f.experiments,
+			})
+
+			test(t, f)
+		})
 	}
 }
 
-func TestGetEligibleOffers_OfferInactive(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An INACTIVE offer
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		Active:       false, // Inactive!
-		MinTxnCount:  3,
-		LookbackDays: 30,
-		StartsAt:     now.AddDate(0, 0, -10),
-		EndsAt:       now.AddDate(0, 0, 10),
-	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with enough transactions
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -5)},
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -10)},
-		{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -15)},
+func (f *eligibilityFixture) upsertOffer(t *testing.T, offer *entities.Offer) {
+	t.Helper()
+	if err := f.offers.Upsert(t.Context(), offer); err != nil {
+		t.Fatalf("Expected no error upserting offer, got %v", err)
 	}
-	txnRepo.Insert(transactions)
-
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
+	if f.matches == nil {
+		return
 	}
-	result, err := useCase.Execute(request)
 
-	// Then: User must NOT be eligible (offer is inactive)
+	transactions, err := f.transactions.GetAll(t.Context())
 	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+		t.Fatalf("Expected no error getting transactions, got %v", err)
 	}
-	if len(result.EligibleOffers) != 0 {
-		t.Errorf("expected 0 eligible offers (inactive), got %d", len(result.EligibleOffers))
+	if err := f.matches.ReplaceOffer(t.Context(), offer, matchingTransactions(offer, transactions)); err != nil {
+		t.Fatalf("Expected no error replacing offer matches, got %v", err)
 	}
 }
 
-func TestGetEligibleOffers_OutsideDateRange(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An offer that has already EXPIRED
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		Active:       true,
-		MinTxnCount:  3,
-		LookbackDays: 30,
-		StartsAt:     now.AddDate(0, 0, -60), // 60 days ago
-		EndsAt:       now.AddDate(0, 0, -30), // Expired 30 days ago!
-	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with enough transactions
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -5)},
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -10)},
-		{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -15)},
+func (f *eligibilityFixture) insertTransactions(t *testing.T, transactions []*entities.Transaction) {
+	t.Helper()
+	inserted, err := f.transactions.Insert(t.Context(), transactions)
+	if err != nil {
+		t.Fatalf("Expected no error inserting transactions, got %v", err)
 	}
-	txnRepo.Insert(transactions)
-
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
+	if f.matches == nil {
+		return
 	}
-	result, err := useCase.Execute(request)
 
-	// Then: User must NOT be eligible (offer expired)
+	offers, err := f.offers.GetAll(t.Context())
 	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+		t.Fatalf("Expected no error getting offers, got %v", err)
 	}
-	if len(result.EligibleOffers) != 0 {
-		t.Errorf("expected 0 eligible offers (expired), got %d", len(result.EligibleOffers))
+	for _, offer := range offers {
+		if matched := matchingTransactions(offer, inserted); len(matched) > 0 {
+			if err := f.matches.Add(t.Context(), offer, matched); err != nil {
+				t.Fatalf("Expected no error adding offer matches, got %v", err)
+			}
+		}
 	}
 }
 
-func TestGetEligibleOffers_TransactionsOutsideLookbackWindow(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An active offer with 30 days lookback
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		Active:       true,
-		MinTxnCount:  3,
-		LookbackDays: 30, // Last 30 days only
-		StartsAt:     now.AddDate(0, 0, -60),
-		EndsAt:       now.AddDate(0, 0, 10),
+func matchingTransactions(offer *entities.Offer, transactions []*entities.Transaction) []*entities.Transaction {
+	var matched []*entities.Transaction
+	for _, transaction := range transactions {
+		if offer.Matches(transaction) {
+			matched = append(matched, transaction)
+		}
 	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with transactions that are TOO OLD (outside lookback window)
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -40)}, // 40 days ago
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -50)}, // 50 days ago
-		{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
-			0, -60)}, // 60 days ago
-	}
-	txnRepo.Insert(transactions)
+	return matched
+}
 
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
-	}
-	result, err := useCase.Execute(request)
+func TestGetEligibleOffers_UserQualifies(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An active offer with min txn count of 3 in last 30 days
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  3,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10), // 10 days ago
+			EndsAt:       now.AddDate(0, 0, 10),  // 10 days in the future
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with 3 transactions in the last 30 days
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -5)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -10)},
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -15)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must be eligible for the offer
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+		if result.EligibleOffers[0].OfferID != "offer-1" {
+			t.Errorf("expected offer-1, got %s", result.EligibleOffers[0].OfferID)
+		}
+		expectedReason := ">= 3 transactions in last 30 days"
+		if result.EligibleOffers[0].Reason != expectedReason {
+			t.Errorf("expected reason '%s', got '%s'", expectedReason, result.EligibleOffers[0].Reason)
+		}
+	})
+}
 
-	// Then: User must NOT be eligible (transactions too old)
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if len(result.EligibleOffers) != 0 {
-		t.Errorf("expected 0 eligible offers (txns too old), got %d", len(result.EligibleOffers))
-	}
+func TestGetEligibleOffers_NotEnoughTransactions(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An active offer requiring 3 transactions
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  3,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10),
+			EndsAt:       now.AddDate(0, 0, 10),
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with only 2 transactions (not enough!)
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -5)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -10)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must NOT be eligible
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers, got %d", len(result.EligibleOffers))
+		}
+	})
+}
+
+func TestGetEligibleOffers_OfferInactive(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An INACTIVE offer
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       false, // Inactive!
+			MinTxnCount:  3,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10),
+			EndsAt:       now.AddDate(0, 0, 10),
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with enough transactions
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -5)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -10)},
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -15)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must NOT be eligible (offer is inactive)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers (inactive), got %d", len(result.EligibleOffers))
+		}
+	})
+}
+
+func TestGetEligibleOffers_OutsideDateRange(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An offer that has already EXPIRED
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  3,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -60), // 60 days ago
+			EndsAt:       now.AddDate(0, 0, -30), // Expired 30 days ago!
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with enough transactions
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -5)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -10)},
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -15)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must NOT be eligible (offer expired)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers (expired), got %d", len(result.EligibleOffers))
+		}
+	})
+}
+
+func TestGetEligibleOffers_TransactionsOutsideLookbackWindow(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An active offer with 30 days lookback
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  3,
+			LookbackDays: 30, // Last 30 days only
+			StartsAt:     now.AddDate(0, 0, -60),
+			EndsAt:       now.AddDate(0, 0, 10),
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with transactions that are TOO OLD (outside lookback window)
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -40)}, // 40 days ago
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -50)}, // 50 days ago
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0,
+				0, -60)}, // 60 days ago
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must NOT be eligible (transactions too old)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers (txns too old), got %d", len(result.EligibleOffers))
+		}
+	})
 }
 
 func TestGetEligibleOffers_MatchByMCC(t *testing.T) {
-	offerRepo := repositories.NewInMemoryOfferRepository()
-	txnRepo := repositories.NewInMemoryTransactionRepository()
-	useCase := use_cases.NewGetEligibleOffersUseCase(offerRepo, txnRepo)
-
-	// Given: An active offer matching by MCC whitelist
-	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
-	offer := &entities.Offer{
-		ID:           "offer-1",
-		MerchantID:   "merchant-1",
-		MCCWhitelist: []string{"5812", "5814"}, // Restaurant MCCs
-		Active:       true,
-		MinTxnCount:  2,
-		LookbackDays: 30,
-		StartsAt:     now.AddDate(0, 0, -10),
-		EndsAt:       now.AddDate(0, 0, 10),
-	}
-	offerRepo.Upsert(offer)
-
-	// And: A user with transactions at DIFFERENT merchants but matching MCCs
-	userID := "user-1"
-	transactions := []*entities.Transaction{
-		{ID: "txn-1", UserID: userID, MerchantID: "merchant-2", MCC: "5812", ApprovedAt: now.AddDate(0,
-			0, -5)}, // Different merchant, but matching MCC
-		{ID: "txn-2", UserID: userID, MerchantID: "merchant-3", MCC: "5814", ApprovedAt: now.AddDate(0,
-			0, -10)}, // Different merchant, but matching MCC
-	}
-	txnRepo.Insert(transactions)
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An active offer matching by MCC whitelist
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			MCCWhitelist: []string{"5812", "5814"}, // Restaurant MCCs
+			Active:       true,
+			MinTxnCount:  2,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10),
+			EndsAt:       now.AddDate(0, 0, 10),
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with transactions at DIFFERENT merchants but matching MCCs
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-2", MCC: "5812", ApprovedAt: now.AddDate(0,
+				0, -5)}, // Different merchant, but matching MCC
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-3", MCC: "5814", ApprovedAt: now.AddDate(0,
+				0, -10)}, // Different merchant, but matching MCC
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		request := &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    now,
+		}
+		result, err := f.useCase.Execute(t.Context(), request)
+
+		// Then: User must be eligible (matched by MCC)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+		if result.EligibleOffers[0].OfferID != "offer-1" {
+			t.Errorf("expected offer-1, got %s", result.EligibleOffers[0].OfferID)
+		}
+		expectedReason := ">= 2 transactions in last 30 days"
+		if result.EligibleOffers[0].Reason != expectedReason {
+			t.Errorf("expected reason '%s', got '%s'", expectedReason, result.EligibleOffers[0].Reason)
+		}
+	})
+}
 
-	// When: We call the use case
-	request := &dtos.GetEligibleOffersRequest{
-		UserID: userID,
-		Now:    now,
-	}
-	result, err := useCase.Execute(request)
+func TestGetEligibleOffers_RecurringSchedule(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: A happy-hour offer live Fri-Sun 17:00-22:00 in Sao Paulo (UTC-3)
+		schedule, err := entities.NewSchedule("America/Sao_Paulo", []time.Weekday{time.Friday, time.Saturday, time.Sunday}, "17:00", "22:00", true)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		friday := time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  2,
+			LookbackDays: 30,
+			StartsAt:     friday.AddDate(0, 0, -30),
+			EndsAt:       friday.AddDate(0, 0, 30),
+			Schedule:     schedule,
+		}
+		f.upsertOffer(t, offer)
+
+		// And: Two transactions inside past happy hours and one outside
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 17, 21, 0, 0, 0, time.UTC)}, // Fri 18:00 local
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 19, 0, 30, 0, 0, time.UTC)}, // Sat 21:30 local
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 21, 21, 0, 0, 0, time.UTC)}, // Tue 18:00 local
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We check eligibility during Friday's happy hour
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    time.Date(2025, 10, 24, 22, 0, 0, 0, time.UTC), // Fri 19:00 local
+		})
+
+		// Then: User must be eligible, only in-window transactions counted
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+
+		// When: We check eligibility outside the schedule
+		result, err = f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{
+			UserID: userID,
+			Now:    time.Date(2025, 10, 24, 15, 0, 0, 0, time.UTC), // Fri 12:00 local
+		})
+
+		// Then: The offer is not live
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers (outside schedule), got %d", len(result.EligibleOffers))
+		}
+	})
+}
 
-	// Then: User must be eligible (matched by MCC)
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if len(result.EligibleOffers) != 1 {
-		t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
-	}
-	if result.EligibleOffers[0].OfferID != "offer-1" {
-		t.Errorf("expected offer-1, got %s", result.EligibleOffers[0].OfferID)
-	}
-	expectedReason := ">= 2 transactions in last 30 days"
-	if result.EligibleOffers[0].Reason != expectedReason {
-		t.Errorf("expected reason '%s', got '%s'", expectedReason, result.EligibleOffers[0].Reason)
-	}
+func TestGetEligibleOffers_ScheduleExcludesTransactionsOutsideWindow(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An overnight offer (22:00-02:00 UTC, every day) that only counts in-window transactions,
+		// with its schedule built as a literal rather than with NewSchedule
+		schedule := &entities.Schedule{Timezone: "UTC", StartTime: "22:00", EndTime: "02:00", ApplyToTransactions: true}
+		now := time.Date(2025, 10, 21, 23, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  2,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10),
+			EndsAt:       now.AddDate(0, 0, 10),
+			Schedule:     schedule,
+		}
+		f.upsertOffer(t, offer)
+
+		// And: One transaction after midnight and one in the afternoon
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 20, 1, 0, 0, 0, time.UTC)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 20, 15, 0, 0, 0, time.UTC)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: User must NOT be eligible (afternoon transaction does not count)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers, got %d", len(result.EligibleOffers))
+		}
+
+		// And: The offer is live and the after-midnight transaction counts toward it
+		if len(result.InProgressOffers) != 1 || result.InProgressOffers[0].Progress.TransactionCount.Current != 1 {
+			t.Errorf("expected the offer in progress with 1 transaction, got %+v", result.InProgressOffers)
+		}
+	})
+}
+
+func TestGetEligibleOffers_CalendarMonthInOfferTimezone(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: A "this calendar month" offer evaluated in Sao Paulo (UTC-3)
+		saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+		now := time.Date(2025, 11, 1, 2, 0, 0, 0, time.UTC) // still Oct 31 23:00 in Sao Paulo
+		offer := &entities.Offer{
+			ID:          "offer-1",
+			MerchantID:  "merchant-1",
+			Active:      true,
+			MinTxnCount: 2,
+			StartsAt:    now.AddDate(0, -2, 0),
+			EndsAt:      now.AddDate(0, 2, 0),
+			WindowType:  entities.WindowCalendarMonth,
+			Location:    saoPaulo,
+		}
+		f.upsertOffer(t, offer)
+
+		// And: Two transactions made in October local time
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 1, 4, 0, 0, 0, time.UTC)},   // Oct 1 01:00 local
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC)}, // Oct 31 09:00 local
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 1, 2, 0, 0, 0, time.UTC)},   // Sep 30 23:00 local
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: User is eligible and the window is the local calendar month
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+		eligible := result.EligibleOffers[0]
+		if expected := time.Date(2025, 10, 1, 0, 0, 0, 0, saoPaulo); !eligible.WindowStart.Equal(expected) {
+			t.Errorf("expected window start %v, got %v", expected, eligible.WindowStart)
+		}
+		if !eligible.WindowEnd.Equal(now) {
+			t.Errorf("expected window end %v, got %v", now, eligible.WindowEnd)
+		}
+		if expectedReason := ">= 2 transactions this calendar month"; eligible.Reason != expectedReason {
+			t.Errorf("expected reason '%s', got '%s'", expectedReason, eligible.Reason)
+		}
+	})
+}
+
+func TestGetEligibleOffers_PreviousWeek(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: A "previous week" offer
+		now := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC) // Wednesday
+		offer := &entities.Offer{
+			ID:          "offer-1",
+			MerchantID:  "merchant-1",
+			Active:      true,
+			MinTxnCount: 2,
+			StartsAt:    now.AddDate(0, -1, 0),
+			EndsAt:      now.AddDate(0, 1, 0),
+			WindowType:  entities.WindowPreviousWeek,
+		}
+		f.upsertOffer(t, offer)
+
+		// And: One transaction last week and one this week
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)}, // last Monday
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)}, // this Monday
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: User must NOT be eligible (only one transaction in the previous week)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers, got %d", len(result.EligibleOffers))
+		}
+	})
+}
+
+func TestGetEligibleOffers_DistinctMerchants(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An offer requiring purchases at 3 different coffee shops
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:                   "offer-1",
+			MerchantID:           "merchant-1",
+			MCCWhitelist:         []string{"5814"},
+			Active:               true,
+			MinTxnCount:          3,
+			LookbackDays:         30,
+			StartsAt:             now.AddDate(0, 0, -10),
+			EndsAt:               now.AddDate(0, 0, 10),
+			MinDistinctMerchants: 3,
+		}
+		f.upsertOffer(t, offer)
+
+		// And: A user with 3 transactions at only 2 merchants
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "coffee-1", MCC: "5814", ApprovedAt: now.AddDate(0, 0, -1)},
+			{ID: "txn-2", UserID: userID, MerchantID: "coffee-1", MCC: "5814", ApprovedAt: now.AddDate(0, 0, -2)},
+			{ID: "txn-3", UserID: userID, MerchantID: "coffee-2", MCC: "5814", ApprovedAt: now.AddDate(0, 0, -3)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: User is not eligible yet, with progress reported
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Fatalf("expected 0 eligible offers, got %d", len(result.EligibleOffers))
+		}
+		if len(result.InProgressOffers) != 1 {
+			t.Fatalf("expected 1 in-progress offer, got %d", len(result.InProgressOffers))
+		}
+		progress := result.InProgressOffers[0].Progress
+		if progress.DistinctMerchants == nil || progress.DistinctMerchants.Current != 2 || progress.DistinctMerchants.Required != 3 {
+			t.Errorf("expected distinct merchants progress 2/3, got %+v", progress.DistinctMerchants)
+		}
+
+		// When: The user shops at a third coffee shop
+		f.insertTransactions(t, []*entities.Transaction{
+			{ID: "txn-4", UserID: userID, MerchantID: "coffee-3", MCC: "5814", ApprovedAt: now.AddDate(0, 0, -1)},
+		})
+		result, err = f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: User becomes eligible
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+		expectedReason := ">= 3 transactions in last 30 days, >= 3 distinct merchants"
+		if result.EligibleOffers[0].Reason != expectedReason {
+			t.Errorf("expected reason '%s', got '%s'", expectedReason, result.EligibleOffers[0].Reason)
+		}
+	})
+}
+
+func TestGetEligibleOffers_WeeklyStreak(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An offer requiring a purchase every week for 4 weeks
+		now := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC) // Wednesday
+		offer := &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  1,
+			LookbackDays: 60,
+			StartsAt:     now.AddDate(0, -2, 0),
+			EndsAt:       now.AddDate(0, 1, 0),
+			Streak:       &entities.Streak{Period: entities.StreakWeekly, Count: 4},
+		}
+		f.upsertOffer(t, offer)
+
+		// And: Purchases in each of the last 4 complete weeks, none yet this week
+		userID := "user-1"
+		transactions := []*entities.Transaction{
+			{ID: "txn-1", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 9, 23, 12, 0, 0, 0, time.UTC)},
+			{ID: "txn-2", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 4, 12, 0, 0, 0, time.UTC)},
+			{ID: "txn-3", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)},
+			{ID: "txn-4", UserID: userID, MerchantID: "merchant-1", ApprovedAt: time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)},
+		}
+		f.insertTransactions(t, transactions)
+
+		// When: We call the use case
+		result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+
+		// Then: The streak is still intact and the user qualifies
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 1 {
+			t.Fatalf("expected 1 eligible offer, got %d", len(result.EligibleOffers))
+		}
+		if streak := result.EligibleOffers[0].Progress.Streak; streak == nil || streak.Current != 4 {
+			t.Errorf("expected streak of 4, got %+v", streak)
+		}
+
+		// When: A week is missed
+		result, err = f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now.AddDate(0, 0, 7)})
+
+		// Then: The streak is broken
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.EligibleOffers) != 0 {
+			t.Errorf("expected 0 eligible offers (streak broken), got %d", len(result.EligibleOffers))
+		}
+	})
+}
+
+func TestGetEligibleOffers_SegmentTargeting(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: A premium segment and a staff segment
+		f.segments.Upsert(t.Context(), entities.NewSegment("premium", "Premium cardholders", ""))
+		f.segments.Upsert(t.Context(), entities.NewSegment("staff", "Staff", ""))
+		f.segments.AddMembers(t.Context(), "premium", []string{"user-1", "user-2"})
+		f.segments.AddMembers(t.Context(), "staff", []string{"user-2"})
+
+		// And: An offer for premium cardholders that excludes staff
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		offer := &entities.Offer{
+			ID:              "offer-1",
+			MerchantID:      "merchant-1",
+			Active:          true,
+			MinTxnCount:     1,
+			LookbackDays:    30,
+			StartsAt:        now.AddDate(0, 0, -10),
+			EndsAt:          now.AddDate(0, 0, 10),
+			IncludeSegments: []string{"premium"},
+			ExcludeSegments: []string{"staff"},
+		}
+		f.upsertOffer(t, offer)
+
+		// And: Three users with a qualifying transaction each
+		for _, userID := range []string{"user-1", "user-2", "user-3"} {
+			f.insertTransactions(t, []*entities.Transaction{
+				{ID: "txn-" + userID, UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0, 0, -1)},
+			})
+		}
+
+		// When: We check eligibility for each user
+		// Then: Only the premium, non-staff user is eligible
+		expected := map[string]int{"user-1": 1, "user-2": 0, "user-3": 0}
+		for userID, expectedCount := range expected {
+			result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(result.EligibleOffers) != expectedCount {
+				t.Errorf("expected %d eligible offers for %s, got %d", expectedCount, userID, len(result.EligibleOffers))
+			}
+		}
+	})
+}
+
+func TestGetEligibleOffers_AttributePredicates(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		// Given: An offer for gold/platinum users who signed up in the last 90 days
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		recentSignup, _ := entities.NewAttributePredicate("signup_date", "within_days", []string{"90"})
+		premiumTier, _ := entities.NewAttributePredicate("card_tier", "in", []string{"gold", "platinum"})
+		offer := &entities.Offer{
+			ID:                  "offer-1",
+			MerchantID:          "merchant-1",
+			Active:              true,
+			MinTxnCount:         1,
+			LookbackDays:        30,
+			StartsAt:            now.AddDate(0, 0, -10),
+			EndsAt:              now.AddDate(0, 0, 10),
+			AttributePredicates: []*entities.AttributePredicate{recentSignup, premiumTier},
+		}
+		f.upsertOffer(t, offer)
+
+		// And: Users with different profiles (user-4 has none)
+		f.users.Upsert(t.Context(), entities.NewUser("user-1", "BR", now.AddDate(0, 0, -30), "gold", nil))
+		f.users.Upsert(t.Context(), entities.NewUser("user-2", "BR", now.AddDate(0, 0, -120), "platinum", nil))
+		f.users.Upsert(t.Context(), entities.NewUser("user-3", "BR", now.AddDate(0, 0, -30), "standard", nil))
+		for _, userID := range []string{"user-1", "user-2", "user-3", "user-4"} {
+			f.insertTransactions(t, []*entities.Transaction{
+				{ID: "txn-" + userID, UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0, 0, -1)},
+			})
+		}
+
+		// When: We check eligibility for each user
+		// Then: Only the recent gold user is eligible
+		expected := map[string]int{"user-1": 1, "user-2": 0, "user-3": 0, "user-4": 0}
+		for userID, expectedCount := range expected {
+			result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(result.EligibleOffers) != expectedCount {
+				t.Errorf("expected %d eligible offers for %s, got %d", expectedCount, userID, len(result.EligibleOffers))
+			}
+		}
+	})
+}
+
+func TestGetEligibleOffers_ExperimentSuppressesControlGroup(t *testing.T) {
+	runWithMatchReaders(t, func(t *testing.T, f *eligibilityFixture) {
+		assignmentsUseCase := use_cases.NewGetExperimentAssignmentsUseCase(f.experiments)
+
+		// Given: An offer with a 30% control / 50% treatment experiment
+		now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+		f.upsertOffer(t, &entities.Offer{
+			ID:           "offer-1",
+			MerchantID:   "merchant-1",
+			Active:       true,
+			MinTxnCount:  1,
+			LookbackDays: 30,
+			StartsAt:     now.AddDate(0, 0, -10),
+			EndsAt:       now.AddDate(0, 0, 10),
+		})
+		experiment := entities.NewExperiment("exp-1", "offer-1", "lift-test", 30, 50)
+		f.experiments.Upsert(t.Context(), experiment)
+
+		// And: 200 users with a qualifying transaction each
+		userIDs := make([]string, 0, 200)
+		for i := range 200 {
+			userID := fmt.Sprintf("user-%d", i)
+			userIDs = append(userIDs, userID)
+			f.insertTransactions(t, []*entities.Transaction{
+				{ID: "txn-" + userID, UserID: userID, MerchantID: "merchant-1", ApprovedAt: now.AddDate(0, 0, -1)},
+			})
+		}
+
+		// When: The users are only evaluated, as re-evaluation after ingestion does
+		// Then: Control users are suppressed, but no exposure is recorded
+		for _, userID := range userIDs {
+			result, err := f.useCase.Evaluate(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if suppressed := len(result.EligibleOffers) == 0; suppressed != (experiment.Assign(userID) == entities.VariantControl) {
+				t.Fatalf("expected only control users to be suppressed, got suppressed=%v for %s", suppressed, userID)
+			}
+		}
+		if exposures, _ := f.experiments.GetExposures(t.Context(), "exp-1"); len(exposures) != 0 {
+			t.Fatalf("expected no exposures from evaluation alone, got %d", len(exposures))
+		}
+
+		// When: We check eligibility for every user twice
+		// Then: Only control users are suppressed, and assignment is stable across calls
+		expected := map[entities.Variant]int{}
+		for _, userID := range userIDs {
+			variant := experiment.Assign(userID)
+			expected[variant]++
+			for range 2 {
+				result, err := f.useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: userID, Now: now})
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				suppressed := len(result.EligibleOffers) == 0
+				if suppressed != (variant == entities.VariantControl) {
+					t.Fatalf("expected suppressed=%v for %s in %s, got %v", variant == entities.VariantControl, userID, variant, suppressed)
+				}
+			}
+		}
+		if expected[entities.VariantControl] == 0 || expected[entities.VariantTreatment] == 0 || expected[entities.VariantNone] == 0 {
+			t.Fatalf("expected users in every variant, got %v", expected)
+		}
+
+		// And: The assignment summary counts each enrolled user once, with control as would-have-been eligible
+		summary, err := assignmentsUseCase.Execute(t.Context(), &dtos.GetExperimentAssignmentsRequest{ExperimentID: "exp-1"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if summary.TotalUsers != expected[entities.VariantControl]+expected[entities.VariantTreatment] {
+			t.Errorf("expected %d enrolled users, got %d", expected[entities.VariantControl]+expected[entities.VariantTreatment], summary.TotalUsers)
+		}
+		for _, variant := range summary.Variants {
+			if variant.Users != expected[entities.Variant(variant.Variant)] {
+				t.Errorf("expected %d %s users, got %d", expected[entities.Variant(variant.Variant)], variant.Variant, variant.Users)
+			}
+		}
+		if summary.Variants[0].Suppressed != expected[entities.VariantControl] || summary.Variants[1].Suppressed != 0 {
+			t.Errorf("expected only control users to be suppressed, got %+v", summary.Variants)
+		}
+	})
 }
@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type GetExperimentUseCase struct {
+	experimentRepository repositories.ExperimentRepository
+}
+
+func NewGetExperimentUseCase(experimentRepository repositories.ExperimentRepository) *GetExperimentUseCase {
+	return &GetExperimentUseCase{
+		experimentRepository: experimentRepository,
+	}
+}
+
+func (u *GetExperimentUseCase) Execute(ctx context.Context, request *dtos.GetExperimentRequest) (*dtos.ExperimentDto, error) {
+	experiment, err := u.experimentRepository.GetByID(ctx, request.ExperimentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("experiment not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get experiment", err)
+	}
+
+	return newExperimentDto(experiment), nil
+}
@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type GetExperimentAssignmentsUseCase struct {
+	experimentRepository repositories.ExperimentRepository
+}
+
+func NewGetExperimentAssignmentsUseCase(experimentRepository repositories.ExperimentRepository) *GetExperimentAssignmentsUseCase {
+	return &GetExperimentAssignmentsUseCase{
+		experimentRepository: experimentRepository,
+	}
+}
+
+func (u *GetExperimentAssignmentsUseCase) Execute(ctx context.Context, request *dtos.GetExperimentAssignmentsRequest) (*dtos.GetExperimentAssignmentsResponse, error) {
+	experiment, err := u.experimentRepository.GetByID(ctx, request.ExperimentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("experiment not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get experiment", err)
+	}
+
+	exposures, err := u.experimentRepository.GetExposures(ctx, experiment.ID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get experiment exposures", err)
+	}
+
+	variants := []dtos.VariantAssignmentDto{
+		{Variant: string(entities.VariantControl), Percent: experiment.ControlPercent},
+		{Variant: string(entities.VariantTreatment), Percent: experiment.TreatmentPercent},
+	}
+	for _, exposure := range exposures {
+		for i := range variants {
+			if variants[i].Variant != string(exposure.Variant) {
+				continue
+			}
+			variants[i].Users++
+			if exposure.Suppressed {
+				variants[i].Suppressed++
+			}
+		}
+	}
+
+	return &dtos.GetExperimentAssignmentsResponse{
+		ExperimentID: experiment.ID,
+		OfferID:      experiment.OfferID,
+		TotalUsers:   len(exposures),
+		Variants:     variants,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type GetOfferBudgetUseCase struct {
+	offerRepository  repositories.OfferRepository
+	budgetRepository repositories.BudgetRepository
+}
+
+func NewGetOfferBudgetUseCase(offerRepository repositories.OfferRepository, budgetRepository repositories.BudgetRepository) *GetOfferBudgetUseCase {
+	return &GetOfferBudgetUseCase{
+		offerRepository:  offerRepository,
+		budgetRepository: budgetRepository,
+	}
+}
+
+func (u *GetOfferBudgetUseCase) Execute(ctx context.Context, request *dtos.GetOfferBudgetRequest) (*dtos.GetOfferBudgetResponse, error) {
+	offer, err := u.offerRepository.GetByID(ctx, request.OfferID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("offer not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offer", err)
+	}
+
+	usage, err := u.budgetRepository.GetByOfferID(ctx, offer.ID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offer budget", err)
+	}
+
+	response := &dtos.GetOfferBudgetResponse{
+		OfferID:        offer.ID,
+		BudgetCents:    offer.BudgetCents,
+		SpentCents:     usage.SpentCents,
+		MaxRedemptions: offer.MaxRedemptions,
+		Redemptions:    usage.Redemptions,
+		Exhausted:      offer.IsExhausted(usage),
+	}
+
+	// Burn rate is averaged over the part of the campaign that has elapsed so far.
+	elapsedUntil := request.Now
+	if elapsedUntil.After(offer.EndsAt) {
+		elapsedUntil = offer.EndsAt
+	}
+	elapsedDays := elapsedUntil.Sub(offer.StartsAt).Hours() / 24
+	if elapsedDays > 0 {
+		response.BurnRateCentsPerDay = float64(usage.SpentCents) / elapsedDays
+		response.RedemptionsPerDay = float64(usage.Redemptions) / elapsedDays
+	}
+
+	daysLeft := math.Inf(1)
+	if offer.BudgetCents > 0 {
+		remaining := max(offer.BudgetCents-usage.SpentCents, 0)
+		response.RemainingCents = &remaining
+		if response.BurnRateCentsPerDay > 0 {
+			daysLeft = min(daysLeft, float64(remaining)/response.BurnRateCentsPerDay)
+		}
+	}
+	if offer.MaxRedemptions > 0 {
+		remaining := max(offer.MaxRedemptions-usage.Redemptions, 0)
+		response.RemainingRedemptions = &remaining
+		if response.RedemptionsPerDay > 0 {
+			daysLeft = min(daysLeft, float64(remaining)/response.RedemptionsPerDay)
+		}
+	}
+	if !math.IsInf(daysLeft, 1) && !response.Exhausted {
+		projected := request.Now.Add(time.Duration(daysLeft * 24 * float64(time.Hour)))
+		response.ProjectedExhaustionAt = &projected
+	}
+
+	return response, nil
+}
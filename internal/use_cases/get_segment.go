@@ -0,0 +1,42 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type GetSegmentUseCase struct {
+	segmentRepository repositories.SegmentRepository
+}
+
+func NewGetSegmentUseCase(segmentRepository repositories.SegmentRepository) *GetSegmentUseCase {
+	return &GetSegmentUseCase{
+		segmentRepository: segmentRepository,
+	}
+}
+
+func (u *GetSegmentUseCase) Execute(ctx context.Context, request *dtos.GetSegmentRequest) (*dtos.SegmentDto, error) {
+	segment, err := u.segmentRepository.GetByID(ctx, request.SegmentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get segment", err)
+	}
+
+	memberCount, err := u.segmentRepository.CountMembers(ctx, segment.ID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to count segment members", err)
+	}
+
+	return &dtos.SegmentDto{
+		ID:          segment.ID,
+		Name:        segment.Name,
+		Description: segment.Description,
+		MemberCount: memberCount,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type GetUserProfileUseCase struct {
+	userRepository repositories.UserRepository
+}
+
+func NewGetUserProfileUseCase(userRepository repositories.UserRepository) *GetUserProfileUseCase {
+	return &GetUserProfileUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (u *GetUserProfileUseCase) Execute(ctx context.Context, request *dtos.GetUserProfileRequest) (*dtos.UserProfileDto, error) {
+	user, err := u.userRepository.GetByID(ctx, request.UserID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("user profile not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get user profile", err)
+	}
+
+	return newUserProfileDto(user), nil
+}
@@ -1,6 +1,10 @@
 package use_cases
 
 import (
+	"context"
+	"slices"
+	"sync"
+
 	"github.com/Drinnn/eligible-offers-api/internal/dtos"
 	"github.com/Drinnn/eligible-offers-api/internal/entities"
 	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
@@ -8,27 +12,103 @@ import (
 )
 
 type IngestTransactionsUseCase struct {
-	transactionRepository repositories.TransactionRepository
+	transactionRepository        repositories.TransactionRepository
+	offerRepository              repositories.OfferRepository
+	offerMatchRepository         repositories.OfferMatchRepository
+	eligibilityCache             EligibilityCacheInvalidator // optional
+	reevaluateEligibilityUseCase *ReevaluateEligibilityUseCase
+	offerMatchLock               *sync.RWMutex // shared with UpsertOfferUseCase
 }
 
-func NewIngestTransactionsUseCase(transactionRepository repositories.TransactionRepository) *IngestTransactionsUseCase {
+func NewIngestTransactionsUseCase(transactionRepository repositories.TransactionRepository, offerRepository repositories.OfferRepository, offerMatchRepository repositories.OfferMatchRepository, eligibilityCache EligibilityCacheInvalidator, reevaluateEligibilityUseCase *ReevaluateEligibilityUseCase, offerMatchLock *sync.RWMutex) *IngestTransactionsUseCase {
 	return &IngestTransactionsUseCase{
-		transactionRepository: transactionRepository,
+		transactionRepository:        transactionRepository,
+		offerRepository:              offerRepository,
+		offerMatchRepository:         offerMatchRepository,
+		eligibilityCache:             eligibilityCache,
+		reevaluateEligibilityUseCase: reevaluateEligibilityUseCase,
+		offerMatchLock:               offerMatchLock,
 	}
 }
 
-func (u *IngestTransactionsUseCase) Execute(request *dtos.IngestTransactionsRequest) (*dtos.IngestTransactionsResponse, error) {
+func (u *IngestTransactionsUseCase) Execute(ctx context.Context, request *dtos.IngestTransactionsRequest) (*dtos.IngestTransactionsResponse, error) {
 	transactions := make([]*entities.Transaction, len(request.Transactions))
+	userIDs := make([]string, 0)
 	for i, transaction := range request.Transactions {
 		transactions[i] = entities.NewTransaction(transaction.ID, transaction.UserID, transaction.MerchantID, transaction.MCC, transaction.AmountCents, transaction.ApprovedAt)
+		if !slices.Contains(userIDs, transaction.UserID) {
+			userIDs = append(userIDs, transaction.UserID)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	inserted, err := u.transactionRepository.Insert(transactions)
+	// Once transactions are stored their matches must be written too, since a retry
+	// would skip them as duplicates.
+	inserted, err := u.saveTransactions(context.WithoutCancel(ctx), transactions)
 	if err != nil {
-		return nil, customErrors.NewServiceError("failed to ingest transactions")
+		return nil, err
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateUsers(userIDs)
+	}
+
+	// Ingestion is idempotent, so a failed re-evaluation is safe for the client to retry.
+	if _, err := u.reevaluateEligibilityUseCase.Execute(ctx, &dtos.ReevaluateEligibilityRequest{UserIDs: userIDs, Now: request.Now}); err != nil {
+		return nil, err
 	}
 
 	return &dtos.IngestTransactionsResponse{
-		Inserted: inserted,
+		Inserted: len(inserted),
 	}, nil
 }
+
+// saveTransactions stores the transactions and adds their matches to the offers they can match
+// by merchant or MCC. Ingests may run together, but not while an offer upsert is rebuilding
+// that offer's matches.
+func (u *IngestTransactionsUseCase) saveTransactions(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error) {
+	u.offerMatchLock.RLock()
+	defer u.offerMatchLock.RUnlock()
+
+	inserted, err := u.transactionRepository.Insert(ctx, transactions)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to ingest transactions", err)
+	}
+
+	if len(inserted) == 0 {
+		return inserted, nil
+	}
+
+	merchantIDs := make([]string, 0)
+	mccs := make([]string, 0)
+	for _, transaction := range inserted {
+		if !slices.Contains(merchantIDs, transaction.MerchantID) {
+			merchantIDs = append(merchantIDs, transaction.MerchantID)
+		}
+		if !slices.Contains(mccs, transaction.MCC) {
+			mccs = append(mccs, transaction.MCC)
+		}
+	}
+	offers, err := u.offerRepository.GetByMerchantsOrMCCs(ctx, merchantIDs, mccs)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offers", err)
+	}
+	for _, offer := range offers {
+		matched := make([]*entities.Transaction, 0)
+		for _, transaction := range inserted {
+			if offer.Matches(transaction) {
+				matched = append(matched, transaction)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		if err := u.offerMatchRepository.Add(ctx, offer, matched); err != nil {
+			return nil, customErrors.NewServiceError("failed to update offer matches", err)
+		}
+	}
+	return inserted, nil
+}
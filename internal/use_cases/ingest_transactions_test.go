@@ -0,0 +1,70 @@
+package use_cases_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+type recordingOfferMatchRepository struct {
+	*repositories.InMemoryOfferMatchRepository
+	added []string
+}
+
+func (r *recordingOfferMatchRepository) Add(ctx context.Context, offer *entities.Offer, transactions []*entities.Transaction) error {
+	r.added = append(r.added, offer.ID)
+	return r.InMemoryOfferMatchRepository.Add(ctx, offer, transactions)
+}
+
+func TestIngestTransactions_AddsMatchesOnlyForMatchingOffers(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	matchRepo := &recordingOfferMatchRepository{InMemoryOfferMatchRepository: repositories.NewInMemoryOfferMatchRepository()}
+	getEligibleOffersUseCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{OfferRepository: offerRepo, OfferMatchReader: matchRepo})
+	reevaluateUseCase := use_cases.NewReevaluateEligibilityUseCase(getEligibleOffersUseCase, txnRepo, repositories.NewInMemoryEligibilitySnapshotRepository(), repositories.NewInMemoryOutboxRepository())
+	useCase := use_cases.NewIngestTransactionsUseCase(txnRepo, offerRepo, matchRepo, nil, reevaluateUseCase, &sync.RWMutex{})
+
+	// Given: Offers matching by merchant, by MCC, and neither, plus one whose schedule excludes the transaction
+	now := time.Date(2025, 10, 20, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-merchant", MerchantID: "merchant-1", Active: true})
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-mcc", MerchantID: "merchant-2", MCCWhitelist: []string{"5812"}, Active: true})
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-other", MerchantID: "merchant-3", MCCWhitelist: []string{"5411"}, Active: true})
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-night", MerchantID: "merchant-1", Active: true, Schedule: &entities.Schedule{
+		Timezone: "UTC", StartTime: "22:00", EndTime: "23:00", ApplyToTransactions: true,
+	}})
+
+	// When: A batch with a transaction at merchant-1 with MCC 5812 is ingested
+	_, err := useCase.Execute(t.Context(), &dtos.IngestTransactionsRequest{Now: now, Transactions: []dtos.TransactionDto{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", MCC: "5812", AmountCents: 1000, ApprovedAt: now},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: Matches are added only for the offers it matches
+	slices.Sort(matchRepo.added)
+	if !slices.Equal(matchRepo.added, []string{"offer-mcc", "offer-merchant"}) {
+		t.Errorf("expected matches added for offer-mcc and offer-merchant only, got %v", matchRepo.added)
+	}
+
+	// When: The same batch is ingested again
+	matchRepo.added = nil
+	_, err = useCase.Execute(t.Context(), &dtos.IngestTransactionsRequest{Now: now, Transactions: []dtos.TransactionDto{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", MCC: "5812", AmountCents: 1000, ApprovedAt: now},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: Nothing new was inserted, so no matches are added
+	if len(matchRepo.added) != 0 {
+		t.Errorf("expected no matches added for a resent batch, got %v", matchRepo.added)
+	}
+}
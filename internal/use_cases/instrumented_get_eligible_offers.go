@@ -0,0 +1,70 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+// Results of a live offer in an eligibility computation, as reported to an EligibilityObserver.
+const (
+	EligibilityResultEligible    = "eligible"
+	EligibilityResultInProgress  = "in_progress"
+	EligibilityResultNotEligible = "not_eligible"
+)
+
+// EligibilityObserver records the result of each offer live during an eligibility
+// computation, keyed by offer ID.
+type EligibilityObserver interface {
+	ObserveEligibility(results map[string]string)
+}
+
+// InstrumentedGetEligibleOffersUseCase reports each response computed by another executor. It
+// belongs inside the eligibility cache, so cached responses are not counted again. Every offer
+// live at the request's time is reported, including those the user has no matching
+// transaction for or is not targeted by, so the eligible share of an offer's results is its
+// hit rate.
+type InstrumentedGetEligibleOffersUseCase struct {
+	next            GetEligibleOffersExecutor
+	offerRepository repositories.OfferRepository
+	observer        EligibilityObserver
+}
+
+func NewInstrumentedGetEligibleOffersUseCase(next GetEligibleOffersExecutor, offerRepository repositories.OfferRepository, observer EligibilityObserver) *InstrumentedGetEligibleOffersUseCase {
+	return &InstrumentedGetEligibleOffersUseCase{
+		next:            next,
+		offerRepository: offerRepository,
+		observer:        observer,
+	}
+}
+
+func (u *InstrumentedGetEligibleOffersUseCase) Execute(ctx context.Context, request *dtos.GetEligibleOffersRequest) (*dtos.GetEligibleOffersResponse, error) {
+	response, err := u.next.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response leaves out the offers the user made no progress on, so the live offers are
+	// read again. Failing to read them only loses this computation's results.
+	offers, err := u.offerRepository.GetActiveAt(ctx, request.Now)
+	if err != nil {
+		return response, nil
+	}
+	results := make(map[string]string, len(offers))
+	for _, offer := range offers {
+		if offer.IsLiveAt(request.Now) {
+			results[offer.ID] = EligibilityResultNotEligible
+		}
+	}
+	for _, offer := range response.InProgressOffers {
+		results[offer.OfferID] = EligibilityResultInProgress
+	}
+	for _, offer := range response.EligibleOffers {
+		results[offer.OfferID] = EligibilityResultEligible
+	}
+
+	u.observer.ObserveEligibility(results)
+
+	return response, nil
+}
@@ -0,0 +1,46 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ListDeadLettersUseCase struct {
+	deliveryRepository repositories.WebhookDeliveryRepository
+}
+
+func NewListDeadLettersUseCase(deliveryRepository repositories.WebhookDeliveryRepository) *ListDeadLettersUseCase {
+	return &ListDeadLettersUseCase{
+		deliveryRepository: deliveryRepository,
+	}
+}
+
+func (u *ListDeadLettersUseCase) Execute(ctx context.Context) (*dtos.ListDeadLettersResponse, error) {
+	deliveries, err := u.deliveryRepository.GetByStatus(ctx, entities.WebhookDeliveryDeadLettered)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get dead-lettered deliveries", err)
+	}
+
+	deliveryDtos := make([]dtos.WebhookDeliveryDto, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryDtos = append(deliveryDtos, dtos.WebhookDeliveryDto{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			EventType:      string(delivery.EventType),
+			Status:         string(delivery.Status),
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt,
+			DeliveredAt:    delivery.DeliveredAt,
+		})
+	}
+
+	return &dtos.ListDeadLettersResponse{Deliveries: deliveryDtos}, nil
+}
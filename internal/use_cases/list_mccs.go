@@ -0,0 +1,66 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ListMCCsUseCase struct {
+	mccRepository repositories.MCCRepository
+}
+
+func NewListMCCsUseCase(mccRepository repositories.MCCRepository) *ListMCCsUseCase {
+	return &ListMCCsUseCase{
+		mccRepository: mccRepository,
+	}
+}
+
+func (u *ListMCCsUseCase) Execute(ctx context.Context, request *dtos.ListMCCsRequest) (*dtos.ListMCCsResponse, error) {
+	categories, err := u.mccRepository.GetCategories(ctx)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get mcc categories", err)
+	}
+
+	var mccs []*entities.MCC
+	if request.Category != "" {
+		mccs, err = u.mccRepository.GetByCategory(ctx, request.Category)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, customErrors.NewFieldValidationError("Invalid category parameter", map[string]customErrors.FieldMessage{
+				"category": customErrors.NewFieldMessage("unknown_mcc_category", "unknown mcc category {0}", request.Category),
+			})
+		}
+	} else {
+		mccs, err = u.mccRepository.GetAll(ctx)
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get mccs", err)
+	}
+
+	response := &dtos.ListMCCsResponse{
+		Categories: make([]dtos.MCCCategoryDto, 0, len(categories)),
+		MCCs:       make([]dtos.MCCDto, 0, len(mccs)),
+	}
+	for _, category := range categories {
+		if request.Category != "" && category.Name != entities.NormalizeMCCCategory(request.Category) {
+			continue
+		}
+		response.Categories = append(response.Categories, dtos.MCCCategoryDto{
+			Name:        category.Name,
+			Description: category.Description,
+		})
+	}
+	for _, mcc := range mccs {
+		response.MCCs = append(response.MCCs, dtos.MCCDto{
+			Code:        mcc.Code,
+			Description: mcc.Description,
+			Category:    mcc.Category,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,47 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ListSegmentsUseCase struct {
+	segmentRepository repositories.SegmentRepository
+}
+
+func NewListSegmentsUseCase(segmentRepository repositories.SegmentRepository) *ListSegmentsUseCase {
+	return &ListSegmentsUseCase{
+		segmentRepository: segmentRepository,
+	}
+}
+
+func (u *ListSegmentsUseCase) Execute(ctx context.Context) (*dtos.ListSegmentsResponse, error) {
+	segments, err := u.segmentRepository.GetAll(ctx)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get segments", err)
+	}
+
+	segmentDtos := make([]dtos.SegmentDto, 0, len(segments))
+	for _, segment := range segments {
+		memberCount, err := u.segmentRepository.CountMembers(ctx, segment.ID)
+		if err != nil {
+			return nil, customErrors.NewServiceError("failed to count segment members", err)
+		}
+		segmentDtos = append(segmentDtos, dtos.SegmentDto{
+			ID:          segment.ID,
+			Name:        segment.Name,
+			Description: segment.Description,
+			MemberCount: memberCount,
+		})
+	}
+	slices.SortFunc(segmentDtos, func(a, b dtos.SegmentDto) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &dtos.ListSegmentsResponse{Segments: segmentDtos}, nil
+}
@@ -0,0 +1,38 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ListWebhookSubscriptionsUseCase struct {
+	subscriptionRepository repositories.WebhookSubscriptionRepository
+}
+
+func NewListWebhookSubscriptionsUseCase(subscriptionRepository repositories.WebhookSubscriptionRepository) *ListWebhookSubscriptionsUseCase {
+	return &ListWebhookSubscriptionsUseCase{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+func (u *ListWebhookSubscriptionsUseCase) Execute(ctx context.Context) (*dtos.ListWebhookSubscriptionsResponse, error) {
+	subscriptions, err := u.subscriptionRepository.GetAll(ctx)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get webhook subscriptions", err)
+	}
+
+	subscriptionDtos := make([]dtos.WebhookSubscriptionDto, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionDtos = append(subscriptionDtos, newWebhookSubscriptionDto(subscription))
+	}
+	slices.SortFunc(subscriptionDtos, func(a, b dtos.WebhookSubscriptionDto) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return &dtos.ListWebhookSubscriptionsResponse{Subscriptions: subscriptionDtos}, nil
+}
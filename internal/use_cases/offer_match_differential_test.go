@@ -0,0 +1,171 @@
+package use_cases_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+func TestGetEligibleOffers_MaterializedMatchesOnTheFly(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	matchRepo := repositories.NewInMemoryOfferMatchRepository()
+	mccRepo, err := repositories.NewEmbeddedMCCRepository()
+	if err != nil {
+		t.Fatalf("failed to load mcc catalog: %v", err)
+	}
+	newGetEligibleOffersUseCase := func(matches repositories.OfferMatchReader) *use_cases.GetEligibleOffersUseCase {
+		return newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{OfferRepository: offerRepo, OfferMatchReader: matches})
+	}
+	onTheFlyUseCase := newGetEligibleOffersUseCase(repositories.NewScanOfferMatchReader(txnRepo))
+	materializedUseCase := newGetEligibleOffersUseCase(matchRepo)
+
+	offerMatchLock := &sync.RWMutex{}
+	reevaluateUseCase := use_cases.NewReevaluateEligibilityUseCase(materializedUseCase, txnRepo, repositories.NewInMemoryEligibilitySnapshotRepository(), repositories.NewInMemoryOutboxRepository())
+	ingestUseCase := use_cases.NewIngestTransactionsUseCase(txnRepo, offerRepo, matchRepo, nil, reevaluateUseCase, offerMatchLock)
+	upsertOfferUseCase := use_cases.NewUpsertOfferUseCase(use_cases.UpsertOfferDependencies{
+		OfferRepository:              offerRepo,
+		MCCRepository:                mccRepo,
+		SegmentRepository:            repositories.NewInMemorySegmentRepository(),
+		TransactionRepository:        txnRepo,
+		OfferMatchRepository:         matchRepo,
+		ReevaluateEligibilityUseCase: reevaluateUseCase,
+		SegmentLock:                  &sync.RWMutex{},
+		OfferMatchLock:               offerMatchLock,
+	})
+
+	// Given: Random offers and transactions, with offers created, changed and transactions
+	// (including duplicates) ingested in an interleaved order
+	rng := rand.New(rand.NewPCG(37, 1))
+	base := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	merchants := []string{"merchant-0", "merchant-1", "merchant-2", "merchant-3", "merchant-4"}
+	mccs := []string{"5411", "5541", "5812", "5814"}
+	windowTypes := []string{"rolling", "calendar_month", "previous_week", "since_offer_start"}
+	userIDs := make([]string, 20)
+	for i := range userIDs {
+		userIDs[i] = fmt.Sprintf("user-%d", i)
+	}
+
+	randomOffer := func(id string) *dtos.UpsertOfferRequest {
+		request := &dtos.UpsertOfferRequest{
+			ID:           id,
+			MerchantID:   merchants[rng.IntN(len(merchants))],
+			MCCWhitelist: []string{mccs[rng.IntN(len(mccs))]},
+			Active:       rng.IntN(5) > 0,
+			MinTxnCount:  1 + rng.IntN(4),
+			LookbackDays: 1 + rng.IntN(45),
+			StartsAt:     base.AddDate(0, 0, rng.IntN(20)),
+			EndsAt:       base.AddDate(0, 0, 40+rng.IntN(20)),
+			WindowType:   windowTypes[rng.IntN(len(windowTypes))],
+			Now:          base,
+		}
+		if rng.IntN(3) == 0 {
+			request.MinDistinctMerchants = 1 + rng.IntN(3)
+		}
+		if rng.IntN(4) == 0 {
+			request.Streak = &dtos.StreakDto{Period: []string{"day", "week"}[rng.IntN(2)], Count: 1 + rng.IntN(3)}
+		}
+		if rng.IntN(4) == 0 {
+			request.Schedule = &dtos.ScheduleDto{Timezone: "America/Sao_Paulo", StartTime: "08:00", EndTime: "20:00", ApplyToTransactions: true}
+		}
+		return request
+	}
+
+	txnCount := 0
+	randomBatch := func() *dtos.IngestTransactionsRequest {
+		batch := make([]dtos.TransactionDto, 0)
+		for range 1 + rng.IntN(10) {
+			id := fmt.Sprintf("txn-%d", txnCount)
+			if txnCount > 0 && rng.IntN(10) == 0 {
+				id = fmt.Sprintf("txn-%d", rng.IntN(txnCount))
+			} else {
+				txnCount++
+			}
+			batch = append(batch, dtos.TransactionDto{
+				ID:          id,
+				UserID:      userIDs[rng.IntN(len(userIDs))],
+				MerchantID:  merchants[rng.IntN(len(merchants))],
+				MCC:         mccs[rng.IntN(len(mccs))],
+				AmountCents: 100,
+				ApprovedAt:  base.Add(time.Duration(rng.IntN(60*24)) * time.Hour),
+			})
+		}
+		return &dtos.IngestTransactionsRequest{Transactions: batch, Now: base}
+	}
+
+	for step := range 60 {
+		if step%6 == 0 {
+			offerID := fmt.Sprintf("offer-%d", rng.IntN(12))
+			if _, err := upsertOfferUseCase.Execute(t.Context(), randomOffer(offerID)); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			continue
+		}
+		if _, err := ingestUseCase.Execute(t.Context(), randomBatch()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// And: More offers changed while transactions are ingested concurrently
+	upserts := make([]*dtos.UpsertOfferRequest, 0)
+	for range 10 {
+		upserts = append(upserts, randomOffer(fmt.Sprintf("offer-%d", rng.IntN(12))))
+	}
+	ingests := make([]*dtos.IngestTransactionsRequest, 0)
+	for range 40 {
+		ingests = append(ingests, randomBatch())
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(upserts)+len(ingests))
+	for _, request := range upserts {
+		wg.Go(func() {
+			if _, err := upsertOfferUseCase.Execute(t.Context(), request); err != nil {
+				errs <- err
+			}
+		})
+	}
+	for _, request := range ingests {
+		wg.Go(func() {
+			if _, err := ingestUseCase.Execute(t.Context(), request); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// When: Eligibility is read through both paths at several points in time
+	// Then: The responses are identical
+	compared := 0
+	for day := 0; day < 60; day += 3 {
+		now := base.AddDate(0, 0, day).Add(15 * time.Hour)
+		for _, userID := range userIDs {
+			request := &dtos.GetEligibleOffersRequest{UserID: userID, Now: now}
+			expected, err := onTheFlyUseCase.Execute(t.Context(), request)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			actual, err := materializedUseCase.Execute(t.Context(), request)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("results differ for %s at %s:\non-the-fly:   %+v\nmaterialized: %+v", userID, now, expected, actual)
+			}
+			compared += len(expected.EligibleOffers) + len(expected.InProgressOffers)
+		}
+	}
+	if compared == 0 {
+		t.Fatalf("expected the random data to produce eligible or in-progress offers")
+	}
+}
@@ -0,0 +1,189 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type OfferRuleSeverity string
+
+const (
+	OfferRuleError   OfferRuleSeverity = "error"   // rejects the offer
+	OfferRuleWarning OfferRuleSeverity = "warning" // saves the offer and reports the problem
+	OfferRuleOff     OfferRuleSeverity = "off"
+)
+
+// OfferRules configures the semantic checks run on every offer upsert. Rules missing from
+// Severities are off, and a zero limit turns off the rule that uses it.
+type OfferRules struct {
+	MaxLookbackDays int
+	MaxTxnsPerDay   int
+	Severities      map[string]OfferRuleSeverity
+}
+
+// NewOfferRules builds rules from severities written as strings, as they appear in config.
+func NewOfferRules(maxLookbackDays, maxTxnsPerDay int, severities map[string]string) OfferRules {
+	rules := OfferRules{
+		MaxLookbackDays: maxLookbackDays,
+		MaxTxnsPerDay:   maxTxnsPerDay,
+		Severities:      make(map[string]OfferRuleSeverity, len(severities)),
+	}
+	for rule, severity := range severities {
+		rules.Severities[rule] = OfferRuleSeverity(severity)
+	}
+	return rules
+}
+
+// offerRule adds a message per problem field to problems. It sees the request, for what the
+// client sent, and the offer built from it, for resolved values such as the window type.
+// mccCodes holds the codes each mcc_whitelist selector expands to.
+type offerRule struct {
+	name  string
+	check func(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error
+}
+
+var offerRuleChecks = []offerRule{
+	{"ends_at_in_past", checkEndsAtInPast},
+	{"max_lookback_days", checkMaxLookbackDays},
+	{"reachable_min_txn_count", checkReachableMinTxnCount},
+	{"duplicate_mccs", checkDuplicateMCCs},
+	{"unknown_merchant", checkUnknownMerchant},
+}
+
+type offerValidator struct {
+	rules                 OfferRules
+	offerRepository       repositories.OfferRepository
+	transactionRepository repositories.TransactionRepository
+}
+
+// validate runs every rule that is not off. Problems found by error rules are returned as a
+// ValidationError; those found by warning rules are returned as warnings.
+func (v *offerValidator) validate(ctx context.Context, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string) (map[string]customErrors.FieldMessage, error) {
+	fieldErrors := make(map[string]customErrors.FieldMessage)
+	warnings := make(map[string]customErrors.FieldMessage)
+
+	for _, rule := range offerRuleChecks {
+		var problems map[string]customErrors.FieldMessage
+		switch v.rules.Severities[rule.name] {
+		case OfferRuleError:
+			problems = fieldErrors
+		case OfferRuleWarning:
+			problems = warnings
+		default:
+			continue
+		}
+		if err := rule.check(ctx, v, request, offer, mccCodes, problems); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", fieldErrors)
+	}
+	return warnings, nil
+}
+
+func checkEndsAtInPast(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error {
+	if !request.Now.IsZero() && offer.EndsAt.Before(request.Now) {
+		problems["ends_at"] = customErrors.NewFieldMessage("ends_at_in_past", "ends_at is in the past")
+	}
+	return nil
+}
+
+func checkMaxLookbackDays(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error {
+	if v.rules.MaxLookbackDays > 0 && offer.WindowType == entities.WindowRolling && offer.LookbackDays > v.rules.MaxLookbackDays {
+		problems["lookback_days"] = customErrors.NewFieldMessage("max_lookback_days", "lookback_days must be at most {0}", strconv.Itoa(v.rules.MaxLookbackDays))
+	}
+	return nil
+}
+
+// checkReachableMinTxnCount flags counts a user could not reach in the longest window the
+// offer's window type allows, at MaxTxnsPerDay transactions a day.
+func checkReachableMinTxnCount(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error {
+	if v.rules.MaxTxnsPerDay <= 0 {
+		return nil
+	}
+
+	var windowDays int
+	switch offer.WindowType {
+	case entities.WindowCalendarMonth:
+		windowDays = 31
+	case entities.WindowPreviousWeek:
+		windowDays = 7
+	case entities.WindowSinceOfferStart:
+		windowDays = int(math.Ceil(offer.EndsAt.Sub(offer.StartsAt).Hours() / 24))
+	default:
+		windowDays = offer.LookbackDays
+	}
+
+	if maxTxns := windowDays * v.rules.MaxTxnsPerDay; offer.MinTxnCount > maxTxns {
+		problems["min_txn_count"] = customErrors.NewFieldMessage("unreachable_min_txn_count", "min_txn_count cannot be reached in a {0}-day window at {1} transactions per day (at most {2})",
+			strconv.Itoa(windowDays), strconv.Itoa(v.rules.MaxTxnsPerDay), strconv.Itoa(maxTxns))
+	}
+	return nil
+}
+
+func checkDuplicateMCCs(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error {
+	firstSelector := make(map[string]int)
+	for i, codes := range mccCodes {
+		// Report the earliest selector this one overlaps and the codes they share.
+		overlapping, shared := -1, []string(nil)
+		for _, code := range codes {
+			first, exists := firstSelector[code]
+			if !exists {
+				firstSelector[code] = i
+				continue
+			}
+			if first == i {
+				continue
+			}
+			if overlapping == -1 || first < overlapping {
+				overlapping, shared = first, nil
+			}
+			if first == overlapping {
+				shared = append(shared, code)
+			}
+		}
+
+		if overlapping != -1 {
+			slices.Sort(shared)
+			problems[fmt.Sprintf("mcc_whitelist[%d]", i)] = customErrors.NewFieldMessage("overlapping_mccs", "overlaps mcc_whitelist[{0}] ({1}) on mccs {2}",
+				strconv.Itoa(overlapping), strings.TrimSpace(request.MCCWhitelist[overlapping]), strings.Join(shared, ", "))
+		}
+	}
+	return nil
+}
+
+// checkUnknownMerchant flags merchants no transaction or other offer refers to, which usually
+// means a typo in merchant_id. There is no merchant catalog to check against.
+func checkUnknownMerchant(ctx context.Context, v *offerValidator, request *dtos.UpsertOfferRequest, offer *entities.Offer, mccCodes [][]string, problems map[string]customErrors.FieldMessage) error {
+	hasTransactions, err := v.transactionRepository.HasMerchant(ctx, offer.MerchantID)
+	if err != nil {
+		return customErrors.NewServiceError("failed to check merchant transactions", err)
+	}
+	if hasTransactions {
+		return nil
+	}
+
+	offers, err := v.offerRepository.GetAll(ctx)
+	if err != nil {
+		return customErrors.NewServiceError("failed to get offers", err)
+	}
+	for _, existing := range offers {
+		if existing.MerchantID == offer.MerchantID && existing.ID != offer.ID {
+			return nil
+		}
+	}
+
+	problems["merchant_id"] = customErrors.NewFieldMessage("unknown_merchant", "no transactions or other offers refer to merchant {0}", offer.MerchantID)
+	return nil
+}
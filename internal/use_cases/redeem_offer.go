@@ -0,0 +1,105 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type RedeemOfferUseCase struct {
+	offerRepository      repositories.OfferRepository
+	redemptionRepository repositories.RedemptionRepository
+	budgetRepository     repositories.BudgetRepository
+	outboxRepository     repositories.OutboxRepository
+	eligibilityCache     EligibilityCacheInvalidator // optional
+}
+
+func NewRedeemOfferUseCase(offerRepository repositories.OfferRepository, redemptionRepository repositories.RedemptionRepository, budgetRepository repositories.BudgetRepository, outboxRepository repositories.OutboxRepository, eligibilityCache EligibilityCacheInvalidator) *RedeemOfferUseCase {
+	return &RedeemOfferUseCase{
+		offerRepository:      offerRepository,
+		redemptionRepository: redemptionRepository,
+		budgetRepository:     budgetRepository,
+		outboxRepository:     outboxRepository,
+		eligibilityCache:     eligibilityCache,
+	}
+}
+
+func (u *RedeemOfferUseCase) Execute(ctx context.Context, request *dtos.RedeemOfferRequest) (*dtos.RedeemOfferResponse, error) {
+	offer, err := u.offerRepository.GetByID(ctx, request.OfferID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewNotFoundError("offer not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offer", err)
+	}
+
+	if !offer.IsLiveAt(request.Now) {
+		return nil, customErrors.NewConflictError("offer is not active")
+	}
+
+	_, err = u.redemptionRepository.GetActivation(ctx, request.UserID, offer.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewConflictError("offer must be activated before it can be redeemed")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get activation", err)
+	}
+
+	if offer.BudgetCents > 0 && request.AmountCents == 0 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+			"amount_cents": customErrors.NewFieldMessage("amount_required_for_budget", "amount_cents is required for offers with a budget"),
+		})
+	}
+
+	usage, err := u.budgetRepository.Consume(ctx, offer.ID, request.AmountCents, offer.MaxRedemptions, offer.BudgetCents, request.Now)
+	if errors.Is(err, repositories.ErrBudgetExhausted) {
+		return nil, customErrors.NewConflictError("offer budget is exhausted")
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to consume offer budget", err)
+	}
+
+	redemption := entities.NewRedemption(request.UserID, offer.ID, request.AmountCents, request.Now)
+	err = u.redemptionRepository.InsertRedemption(ctx, redemption, offer.SingleUse)
+	if err != nil {
+		if releaseErr := u.budgetRepository.Release(ctx, offer.ID, request.AmountCents); releaseErr != nil {
+			return nil, customErrors.NewServiceError("failed to release offer budget", releaseErr)
+		}
+		if errors.Is(err, repositories.ErrAlreadyRedeemed) {
+			return nil, customErrors.NewConflictError("offer has already been redeemed")
+		}
+		return nil, customErrors.NewServiceError("failed to redeem offer", err)
+	}
+
+	// Consume is atomic, so only the redemption that used up the caps reports exhaustion.
+	// The redemption is already stored, so a failed append is logged rather than reported.
+	if offer.IsExhausted(usage) {
+		event := entities.NewOfferEvent(entities.BudgetExhausted, request.UserID, offer.ID, request.Now)
+		if err := u.outboxRepository.Append(context.WithoutCancel(ctx), []*entities.OfferEvent{event}); err != nil {
+			slog.ErrorContext(ctx, "failed to write budget exhausted event", slog.String("offer_id", offer.ID), slog.String("event_id", event.ID), slog.Any("error", err))
+		}
+	}
+
+	// An exhausted offer disappears for every user, otherwise only this user's status changed.
+	if u.eligibilityCache != nil {
+		if offer.IsExhausted(usage) {
+			u.eligibilityCache.InvalidateAll()
+		} else {
+			u.eligibilityCache.InvalidateUsers([]string{request.UserID})
+		}
+	}
+
+	return &dtos.RedeemOfferResponse{
+		ID:          redemption.ID,
+		UserID:      redemption.UserID,
+		OfferID:     redemption.OfferID,
+		Status:      string(entities.OfferStatusRedeemed),
+		AmountCents: redemption.AmountCents,
+		RedeemedAt:  redemption.RedeemedAt,
+	}, nil
+}
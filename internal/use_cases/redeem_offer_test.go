@@ -0,0 +1,243 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+func TestRedeemOffer_ConcurrentRedemptionsRespectCap(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	budgetRepo := repositories.NewInMemoryBudgetRepository()
+	outboxRepo := repositories.NewInMemoryOutboxRepository()
+	useCase := use_cases.NewRedeemOfferUseCase(offerRepo, redemptionRepo, budgetRepo, outboxRepo, nil)
+
+	// Given: An offer capped at 10 redemptions and a 4000 cents budget
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offer := &entities.Offer{
+		ID:             "offer-1",
+		MerchantID:     "merchant-1",
+		Active:         true,
+		MinTxnCount:    1,
+		LookbackDays:   30,
+		StartsAt:       now.AddDate(0, 0, -10),
+		EndsAt:         now.AddDate(0, 0, 10),
+		MaxRedemptions: 10,
+		BudgetCents:    4000,
+	}
+	offerRepo.Upsert(t.Context(), offer)
+
+	// And: 50 users who activated the offer
+	for i := range 50 {
+		redemptionRepo.Activate(t.Context(), entities.NewActivation(fmt.Sprintf("user-%d", i), offer.ID, now))
+	}
+
+	// When: They all redeem 500 cents concurrently
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := useCase.Execute(t.Context(), &dtos.RedeemOfferRequest{
+				UserID:      fmt.Sprintf("user-%d", i),
+				OfferID:     offer.ID,
+				Now:         now,
+				AmountCents: 500,
+			})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Then: Exactly as many redemptions as the budget allows succeed
+	if succeeded != 8 {
+		t.Errorf("expected 8 successful redemptions, got %d", succeeded)
+	}
+	usage, _ := budgetRepo.GetByOfferID(t.Context(), offer.ID)
+	if usage.SpentCents != 4000 || usage.Redemptions != 8 {
+		t.Errorf("expected 8 redemptions for 4000 cents, got %d for %d cents", usage.Redemptions, usage.SpentCents)
+	}
+
+	// And: A single budget exhausted event is written
+	events, _ := outboxRepo.GetPending(t.Context(), 10)
+	if len(events) != 1 || events[0].Type != entities.BudgetExhausted {
+		t.Errorf("expected one budget.exhausted event, got %+v", events)
+	}
+}
+
+func TestGetEligibleOffers_ExcludesExhaustedOffers(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	budgetRepo := repositories.NewInMemoryBudgetRepository()
+	useCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{
+		OfferRepository:      offerRepo,
+		OfferMatchReader:     repositories.NewScanOfferMatchReader(txnRepo),
+		RedemptionRepository: redemptionRepo,
+		BudgetRepository:     budgetRepo,
+	})
+
+	// Given: An offer capped at a single redemption that was already used up
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offer := &entities.Offer{
+		ID:             "offer-1",
+		MerchantID:     "merchant-1",
+		Active:         true,
+		MinTxnCount:    1,
+		LookbackDays:   30,
+		StartsAt:       now.AddDate(0, 0, -10),
+		EndsAt:         now.AddDate(0, 0, 10),
+		MaxRedemptions: 1,
+	}
+	offerRepo.Upsert(t.Context(), offer)
+	budgetRepo.Consume(t.Context(), offer.ID, 0, offer.MaxRedemptions, offer.BudgetCents, now)
+
+	// And: A user who otherwise qualifies
+	txnRepo.Insert(t.Context(), []*entities.Transaction{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", ApprovedAt: now.AddDate(0, 0, -1)},
+	})
+
+	// When: We call the use case
+	result, err := useCase.Execute(t.Context(), &dtos.GetEligibleOffersRequest{UserID: "user-1", Now: now})
+
+	// Then: The exhausted offer is not returned
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.EligibleOffers) != 0 {
+		t.Errorf("expected 0 eligible offers (exhausted), got %d", len(result.EligibleOffers))
+	}
+}
+
+func TestGetOfferBudget_ReportsBurnRate(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	budgetRepo := repositories.NewInMemoryBudgetRepository()
+	useCase := use_cases.NewGetOfferBudgetUseCase(offerRepo, budgetRepo)
+
+	// Given: A 10000 cents budget offer that spent 2000 cents in its first 4 days
+	startsAt := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	offer := &entities.Offer{
+		ID:          "offer-1",
+		Active:      true,
+		StartsAt:    startsAt,
+		EndsAt:      startsAt.AddDate(0, 1, 0),
+		BudgetCents: 10000,
+	}
+	offerRepo.Upsert(t.Context(), offer)
+	budgetRepo.Consume(t.Context(), offer.ID, 2000, 0, offer.BudgetCents, startsAt.AddDate(0, 0, 1))
+
+	// When: We get the budget status
+	now := startsAt.AddDate(0, 0, 4)
+	result, err := useCase.Execute(t.Context(), &dtos.GetOfferBudgetRequest{OfferID: offer.ID, Now: now})
+
+	// Then: Remaining budget, burn rate and projected exhaustion are reported
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.RemainingCents == nil || *result.RemainingCents != 8000 {
+		t.Errorf("expected 8000 remaining cents, got %v", result.RemainingCents)
+	}
+	if result.BurnRateCentsPerDay != 500 {
+		t.Errorf("expected burn rate of 500 cents/day, got %v", result.BurnRateCentsPerDay)
+	}
+	if expected := now.AddDate(0, 0, 16); result.ProjectedExhaustionAt == nil || !result.ProjectedExhaustionAt.Equal(expected) {
+		t.Errorf("expected projected exhaustion at %v, got %v", expected, result.ProjectedExhaustionAt)
+	}
+}
+
+type failingOfferRepository struct {
+	repositories.OfferRepository
+	err error
+}
+
+func (r *failingOfferRepository) GetByID(ctx context.Context, id string) (*entities.Offer, error) {
+	return nil, r.err
+}
+
+func TestRedeemOffer_ReturnsTypedErrors(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	useCase := use_cases.NewRedeemOfferUseCase(offerRepo, redemptionRepo, repositories.NewInMemoryBudgetRepository(), repositories.NewInMemoryOutboxRepository(), nil)
+
+	// Given: A live offer the user has not activated
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, LookbackDays: 30, StartsAt: now.AddDate(0, 0, -10), EndsAt: now.AddDate(0, 0, 10)})
+
+	// When: We redeem an unknown offer
+	_, err := useCase.Execute(t.Context(), &dtos.RedeemOfferRequest{UserID: "user-1", OfferID: "missing", Now: now})
+
+	// Then: A not found error is returned
+	var notFoundErr *customErrors.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+
+	// When: We redeem the offer without activating it
+	_, err = useCase.Execute(t.Context(), &dtos.RedeemOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: now})
+
+	// Then: A conflict error is returned
+	var conflictErr *customErrors.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("expected ConflictError, got %v", err)
+	}
+}
+
+func TestRedeemOffer_ServiceErrorWrapsRepositoryCause(t *testing.T) {
+	// Given: An offer repository that fails
+	cause := errors.New("connection refused")
+	useCase := use_cases.NewRedeemOfferUseCase(&failingOfferRepository{err: cause}, repositories.NewInMemoryRedemptionRepository(), repositories.NewInMemoryBudgetRepository(), repositories.NewInMemoryOutboxRepository(), nil)
+
+	// When: We redeem an offer
+	_, err := useCase.Execute(t.Context(), &dtos.RedeemOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: time.Now()})
+
+	// Then: The service error keeps a client-safe message and wraps the cause
+	var serviceErr *customErrors.ServiceError
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("expected ServiceError, got %v", err)
+	}
+	if serviceErr.Error() != "failed to get offer" {
+		t.Errorf("expected the message to omit the cause, got %q", serviceErr.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the error to wrap %v", cause)
+	}
+}
+
+func TestRedeemOffer_SucceedsWhenExhaustedEventCannotBeWritten(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	redemptionRepo := repositories.NewInMemoryRedemptionRepository()
+	outboxRepo := &failingOutboxRepository{InMemoryOutboxRepository: repositories.NewInMemoryOutboxRepository(), fail: true}
+	useCase := use_cases.NewRedeemOfferUseCase(offerRepo, redemptionRepo, repositories.NewInMemoryBudgetRepository(), outboxRepo, nil)
+
+	// Given: An activated offer capped at a single redemption and a failing outbox
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true, MinTxnCount: 1, LookbackDays: 30, StartsAt: now.AddDate(0, 0, -10), EndsAt: now.AddDate(0, 0, 10), MaxRedemptions: 1})
+	redemptionRepo.Activate(t.Context(), entities.NewActivation("user-1", "offer-1", now))
+
+	// When: The user redeems it, exhausting the offer
+	result, err := useCase.Execute(t.Context(), &dtos.RedeemOfferRequest{UserID: "user-1", OfferID: "offer-1", Now: now})
+
+	// Then: The stored redemption is reported as successful
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != string(entities.OfferStatusRedeemed) {
+		t.Errorf("expected status redeemed, got %s", result.Status)
+	}
+}
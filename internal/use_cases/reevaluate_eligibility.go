@@ -0,0 +1,88 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ReevaluateEligibilityUseCase struct {
+	getEligibleOffersUseCase *GetEligibleOffersUseCase
+	transactionRepository    repositories.TransactionRepository
+	snapshotRepository       repositories.EligibilitySnapshotRepository
+	outboxRepository         repositories.OutboxRepository
+}
+
+func NewReevaluateEligibilityUseCase(getEligibleOffersUseCase *GetEligibleOffersUseCase, transactionRepository repositories.TransactionRepository, snapshotRepository repositories.EligibilitySnapshotRepository, outboxRepository repositories.OutboxRepository) *ReevaluateEligibilityUseCase {
+	return &ReevaluateEligibilityUseCase{
+		getEligibleOffersUseCase: getEligibleOffersUseCase,
+		transactionRepository:    transactionRepository,
+		snapshotRepository:       snapshotRepository,
+		outboxRepository:         outboxRepository,
+	}
+}
+
+// Execute compares each user's eligible offers against their last snapshot and writes
+// the offers they gained or lost to the outbox.
+func (u *ReevaluateEligibilityUseCase) Execute(ctx context.Context, request *dtos.ReevaluateEligibilityRequest) (*dtos.ReevaluateEligibilityResponse, error) {
+	userIDs := request.UserIDs
+	if userIDs == nil {
+		allUserIDs, err := u.transactionRepository.GetUserIDs(ctx)
+		if err != nil {
+			return nil, customErrors.NewServiceError("failed to get users to re-evaluate", err)
+		}
+		userIDs = allUserIDs
+	}
+
+	eventCount := 0
+	for _, userID := range userIDs {
+		// Users are evaluated independently, so stopping between them leaves nothing half-written.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Users did not request their offers here, so no experiment exposure is recorded.
+		result, err := u.getEligibleOffersUseCase.Evaluate(ctx, &dtos.GetEligibleOffersRequest{UserID: userID, Now: request.Now})
+		if err != nil {
+			return nil, err
+		}
+
+		current := make([]string, len(result.EligibleOffers))
+		for i, offer := range result.EligibleOffers {
+			current[i] = offer.OfferID
+		}
+
+		previous, err := u.snapshotRepository.Swap(ctx, userID, current)
+		if err != nil {
+			return nil, customErrors.NewServiceError("failed to update eligibility snapshot", err)
+		}
+
+		events := make([]*entities.OfferEvent, 0)
+		for _, offerID := range current {
+			if !slices.Contains(previous, offerID) {
+				events = append(events, entities.NewOfferEvent(entities.EligibilityGained, userID, offerID, request.Now))
+			}
+		}
+		for _, offerID := range previous {
+			if !slices.Contains(current, offerID) {
+				events = append(events, entities.NewOfferEvent(entities.EligibilityLost, userID, offerID, request.Now))
+			}
+		}
+
+		if err := u.outboxRepository.Append(ctx, events); err != nil {
+			// Restore the snapshot so the transitions are detected again on the next evaluation.
+			u.snapshotRepository.Swap(context.WithoutCancel(ctx), userID, previous)
+			return nil, customErrors.NewServiceError("failed to write eligibility events", err)
+		}
+		eventCount += len(events)
+	}
+
+	return &dtos.ReevaluateEligibilityResponse{
+		Evaluated: len(userIDs),
+		Events:    eventCount,
+	}, nil
+}
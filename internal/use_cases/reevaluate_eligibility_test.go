@@ -0,0 +1,275 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+func newReevaluateEligibilityUseCase(offerRepo repositories.OfferRepository, txnRepo repositories.TransactionRepository, matchRepo repositories.OfferMatchReader, outboxRepo repositories.OutboxRepository) *use_cases.ReevaluateEligibilityUseCase {
+	getEligibleOffersUseCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{OfferRepository: offerRepo, OfferMatchReader: matchRepo})
+	return use_cases.NewReevaluateEligibilityUseCase(getEligibleOffersUseCase, txnRepo, repositories.NewInMemoryEligibilitySnapshotRepository(), outboxRepo)
+}
+
+func TestReevaluateEligibility_IngestAndOfferUpsertEmitTransitions(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	outboxRepo := repositories.NewInMemoryOutboxRepository()
+	mccRepo, err := repositories.NewEmbeddedMCCRepository()
+	if err != nil {
+		t.Fatalf("failed to load mcc catalog: %v", err)
+	}
+	matchRepo := repositories.NewInMemoryOfferMatchRepository()
+	offerMatchLock := &sync.RWMutex{}
+	reevaluateUseCase := newReevaluateEligibilityUseCase(offerRepo, txnRepo, matchRepo, outboxRepo)
+	ingestUseCase := use_cases.NewIngestTransactionsUseCase(txnRepo, offerRepo, matchRepo, nil, reevaluateUseCase, offerMatchLock)
+	upsertOfferUseCase := use_cases.NewUpsertOfferUseCase(use_cases.UpsertOfferDependencies{
+		OfferRepository:              offerRepo,
+		MCCRepository:                mccRepo,
+		SegmentRepository:            repositories.NewInMemorySegmentRepository(),
+		TransactionRepository:        txnRepo,
+		OfferMatchRepository:         matchRepo,
+		ReevaluateEligibilityUseCase: reevaluateUseCase,
+		SegmentLock:                  &sync.RWMutex{},
+		OfferMatchLock:               offerMatchLock,
+	})
+
+	// Given: An offer requiring 2 transactions at a restaurant
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRequest := &dtos.UpsertOfferRequest{
+		ID:           "offer-1",
+		MerchantID:   "merchant-1",
+		MCCWhitelist: []string{"5812"},
+		Active:       true,
+		MinTxnCount:  2,
+		LookbackDays: 30,
+		StartsAt:     now.AddDate(0, 0, -10),
+		EndsAt:       now.AddDate(0, 0, 10),
+		Now:          now,
+	}
+	if _, err := upsertOfferUseCase.Execute(t.Context(), offerRequest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ingest := func(txnID string) {
+		t.Helper()
+		_, err := ingestUseCase.Execute(t.Context(), &dtos.IngestTransactionsRequest{
+			Transactions: []dtos.TransactionDto{
+				{ID: txnID, UserID: "user-1", MerchantID: "merchant-2", MCC: "5812", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -1)},
+			},
+			Now: now,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// When: The first transaction is ingested
+	ingest("txn-1")
+
+	// Then: No event is written, the user is still in progress
+	pending, _ := outboxRepo.GetPending(t.Context(), 10)
+	if len(pending) != 0 {
+		t.Fatalf("expected 0 events, got %d", len(pending))
+	}
+
+	// When: The second transaction is ingested, twice
+	ingest("txn-2")
+	ingest("txn-2")
+
+	// Then: A single gained event is written
+	pending, _ = outboxRepo.GetPending(t.Context(), 10)
+	if len(pending) != 1 || pending[0].Type != entities.EligibilityGained || pending[0].UserID != "user-1" || pending[0].OfferID != "offer-1" {
+		t.Fatalf("expected one eligibility.gained event for user-1/offer-1, got %+v", pending)
+	}
+
+	// When: The offer is raised to 3 transactions
+	offerRequest.MinTxnCount = 3
+	if _, err := upsertOfferUseCase.Execute(t.Context(), offerRequest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: A lost event is written for the user
+	pending, _ = outboxRepo.GetPending(t.Context(), 10)
+	if len(pending) != 2 || pending[1].Type != entities.EligibilityLost || pending[1].UserID != "user-1" {
+		t.Fatalf("expected an eligibility.lost event for user-1, got %+v", pending)
+	}
+}
+
+func TestReevaluateEligibility_StopsWhenContextIsCanceled(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	outboxRepo := repositories.NewInMemoryOutboxRepository()
+	matchRepo := repositories.NewInMemoryOfferMatchRepository()
+	reevaluateUseCase := newReevaluateEligibilityUseCase(offerRepo, txnRepo, matchRepo, outboxRepo)
+	ingestUseCase := use_cases.NewIngestTransactionsUseCase(txnRepo, offerRepo, matchRepo, nil, reevaluateUseCase, &sync.RWMutex{})
+
+	// Given: A request context that is already canceled
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	// When: We ingest a transaction with it
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	_, err := ingestUseCase.Execute(ctx, &dtos.IngestTransactionsRequest{
+		Transactions: []dtos.TransactionDto{
+			{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -1)},
+		},
+		Now: now,
+	})
+
+	// Then: The cancellation is returned and nothing is stored
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	transactions, _ := txnRepo.GetAll(t.Context())
+	if len(transactions) != 0 {
+		t.Errorf("expected no stored transactions, got %d", len(transactions))
+	}
+
+	// When: We re-evaluate the user with it
+	_, err = reevaluateUseCase.Execute(ctx, &dtos.ReevaluateEligibilityRequest{UserIDs: []string{"user-1"}, Now: now})
+
+	// Then: No user is evaluated
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReevaluateEligibility_SnapshotSurvivesRestart(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	matchReader := repositories.NewScanOfferMatchReader(txnRepo)
+	getEligibleOffersUseCase := newGetEligibleOffersUseCase(use_cases.GetEligibleOffersDependencies{OfferRepository: offerRepo, OfferMatchReader: matchReader})
+	snapshotPath := filepath.Join(t.TempDir(), "eligibility_snapshots.jsonl")
+
+	// Given: A user qualifying for an offer
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{
+		ID:           "offer-1",
+		MerchantID:   "merchant-1",
+		Active:       true,
+		MinTxnCount:  1,
+		LookbackDays: 30,
+		StartsAt:     now.AddDate(0, 0, -10),
+		EndsAt:       now.AddDate(0, 0, 10),
+	})
+	txnRepo.Insert(t.Context(), []*entities.Transaction{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-1", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -1)},
+	})
+
+	reevaluate := func() int {
+		t.Helper()
+		snapshotRepo, err := repositories.NewFileEligibilitySnapshotRepository(snapshotPath)
+		if err != nil {
+			t.Fatalf("failed to open snapshot log: %v", err)
+		}
+		defer snapshotRepo.Close()
+
+		useCase := use_cases.NewReevaluateEligibilityUseCase(getEligibleOffersUseCase, txnRepo, snapshotRepo, repositories.NewInMemoryOutboxRepository())
+		result, err := useCase.Execute(t.Context(), &dtos.ReevaluateEligibilityRequest{Now: now})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return result.Events
+	}
+
+	// When: The user is re-evaluated, then re-evaluated again after reopening the snapshot log
+	first := reevaluate()
+	second := reevaluate()
+
+	// Then: Only the first evaluation reports the gained offer
+	if first != 1 {
+		t.Errorf("expected 1 event before the restart, got %d", first)
+	}
+	if second != 0 {
+		t.Errorf("expected no events after the restart, got %d", second)
+	}
+}
+
+type failingOutboxRepository struct {
+	*repositories.InMemoryOutboxRepository
+	fail bool
+}
+
+func (r *failingOutboxRepository) Append(ctx context.Context, events []*entities.OfferEvent) error {
+	if r.fail {
+		return errors.New("outbox unavailable")
+	}
+	return r.InMemoryOutboxRepository.Append(ctx, events)
+}
+
+func TestReevaluateEligibility_OfferUpsertReevaluatesMatchedUsersWithoutFailing(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	outboxRepo := &failingOutboxRepository{InMemoryOutboxRepository: repositories.NewInMemoryOutboxRepository()}
+	mccRepo, err := repositories.NewEmbeddedMCCRepository()
+	if err != nil {
+		t.Fatalf("failed to load mcc catalog: %v", err)
+	}
+	matchRepo := repositories.NewInMemoryOfferMatchRepository()
+	upsertOfferUseCase := use_cases.NewUpsertOfferUseCase(use_cases.UpsertOfferDependencies{
+		OfferRepository:              offerRepo,
+		MCCRepository:                mccRepo,
+		SegmentRepository:            repositories.NewInMemorySegmentRepository(),
+		TransactionRepository:        txnRepo,
+		OfferMatchRepository:         matchRepo,
+		ReevaluateEligibilityUseCase: newReevaluateEligibilityUseCase(offerRepo, txnRepo, matchRepo, outboxRepo),
+		SegmentLock:                  &sync.RWMutex{},
+		OfferMatchLock:               &sync.RWMutex{},
+	})
+
+	// Given: A user with a restaurant transaction qualifying for a restaurant offer
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	txnRepo.Insert(t.Context(), []*entities.Transaction{
+		{ID: "txn-1", UserID: "user-1", MerchantID: "merchant-2", MCC: "5812", AmountCents: 1000, ApprovedAt: now.AddDate(0, 0, -1)},
+	})
+	offerRequest := &dtos.UpsertOfferRequest{
+		ID:           "offer-1",
+		MerchantID:   "merchant-1",
+		MCCWhitelist: []string{"5812"},
+		Active:       true,
+		MinTxnCount:  1,
+		LookbackDays: 30,
+		StartsAt:     now.AddDate(0, 0, -10),
+		EndsAt:       now.AddDate(0, 0, 10),
+		Now:          now,
+	}
+	if _, err := upsertOfferUseCase.Execute(t.Context(), offerRequest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// When: The offer moves to an MCC the user never transacted at
+	offerRequest.MCCWhitelist = []string{"5411"}
+	if _, err := upsertOfferUseCase.Execute(t.Context(), offerRequest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The user, who only matched the previous version, loses the offer
+	pending, _ := outboxRepo.GetPending(t.Context(), 10)
+	if len(pending) != 2 || pending[1].Type != entities.EligibilityLost || pending[1].UserID != "user-1" {
+		t.Fatalf("expected gained then lost events for user-1, got %+v", pending)
+	}
+
+	// When: The offer moves back while the outbox is failing
+	outboxRepo.fail = true
+	offerRequest.MCCWhitelist = []string{"5812"}
+	_, err = upsertOfferUseCase.Execute(t.Context(), offerRequest)
+
+	// Then: The stored offer is reported as saved
+	if err != nil {
+		t.Fatalf("expected no error after the offer was stored, got %v", err)
+	}
+	offer, _ := offerRepo.GetByID(t.Context(), "offer-1")
+	if !slices.Equal(offer.MCCWhitelist, []string{"5812"}) {
+		t.Errorf("expected the stored offer to be updated, got %v", offer.MCCWhitelist)
+	}
+}
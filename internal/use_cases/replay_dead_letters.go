@@ -0,0 +1,64 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type ReplayDeadLettersUseCase struct {
+	deliveryRepository repositories.WebhookDeliveryRepository
+}
+
+func NewReplayDeadLettersUseCase(deliveryRepository repositories.WebhookDeliveryRepository) *ReplayDeadLettersUseCase {
+	return &ReplayDeadLettersUseCase{
+		deliveryRepository: deliveryRepository,
+	}
+}
+
+// Execute moves dead-lettered deliveries back to pending with a fresh attempt budget.
+func (u *ReplayDeadLettersUseCase) Execute(ctx context.Context, request *dtos.ReplayDeadLettersRequest) (*dtos.ReplayDeadLettersResponse, error) {
+	var deliveries []*entities.WebhookDelivery
+	if len(request.DeliveryIDs) == 0 {
+		deadLettered, err := u.deliveryRepository.GetByStatus(ctx, entities.WebhookDeliveryDeadLettered)
+		if err != nil {
+			return nil, customErrors.NewServiceError("failed to get dead-lettered deliveries", err)
+		}
+		deliveries = deadLettered
+	} else {
+		fieldErrors := make(map[string]customErrors.FieldMessage)
+		for _, id := range request.DeliveryIDs {
+			delivery, err := u.deliveryRepository.GetByID(ctx, id)
+			if errors.Is(err, repositories.ErrNotFound) {
+				fieldErrors["delivery_ids"] = customErrors.NewFieldMessage("unknown_delivery", "delivery {0} does not exist", id)
+				continue
+			}
+			if err != nil {
+				return nil, customErrors.NewServiceError("failed to get delivery", err)
+			}
+			if delivery.Status != entities.WebhookDeliveryDeadLettered {
+				fieldErrors["delivery_ids"] = customErrors.NewFieldMessage("delivery_not_dead_lettered", "delivery {0} is not dead-lettered", id)
+				continue
+			}
+			deliveries = append(deliveries, delivery)
+		}
+		if len(fieldErrors) > 0 {
+			return nil, customErrors.NewFieldValidationError("Invalid request body", fieldErrors)
+		}
+	}
+
+	for _, delivery := range deliveries {
+		delivery.Status = entities.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = request.Now
+		if err := u.deliveryRepository.Update(ctx, delivery); err != nil {
+			return nil, customErrors.NewServiceError("failed to replay delivery", err)
+		}
+	}
+
+	return &dtos.ReplayDeadLettersResponse{Replayed: len(deliveries)}, nil
+}
@@ -0,0 +1,72 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type UpsertExperimentUseCase struct {
+	experimentRepository repositories.ExperimentRepository
+	offerRepository      repositories.OfferRepository
+	eligibilityCache     EligibilityCacheInvalidator // optional
+}
+
+func NewUpsertExperimentUseCase(experimentRepository repositories.ExperimentRepository, offerRepository repositories.OfferRepository, eligibilityCache EligibilityCacheInvalidator) *UpsertExperimentUseCase {
+	return &UpsertExperimentUseCase{
+		experimentRepository: experimentRepository,
+		offerRepository:      offerRepository,
+		eligibilityCache:     eligibilityCache,
+	}
+}
+
+func (u *UpsertExperimentUseCase) Execute(ctx context.Context, request *dtos.UpsertExperimentRequest) (*dtos.ExperimentDto, error) {
+	if request.ControlPercent+request.TreatmentPercent > 100 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+			"treatment_percent": customErrors.NewFieldMessage("experiment_percent_total", "control_percent + treatment_percent must not exceed 100"),
+		})
+	}
+
+	_, err := u.offerRepository.GetByID(ctx, request.OfferID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+			"offer_id": customErrors.NewFieldMessage("unknown_offer", "offer {0} does not exist", request.OfferID),
+		})
+	}
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get offer", err)
+	}
+
+	existing, err := u.experimentRepository.GetByOfferID(ctx, request.OfferID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewServiceError("failed to get offer experiment", err)
+	}
+	if existing != nil && existing.ID != request.ID {
+		return nil, customErrors.NewConflictError("offer already has experiment " + existing.ID)
+	}
+
+	experiment := entities.NewExperiment(request.ID, request.OfferID, request.Salt, request.ControlPercent, request.TreatmentPercent)
+	if err := u.experimentRepository.Upsert(ctx, experiment); err != nil {
+		return nil, customErrors.NewServiceError("failed to upsert experiment", err)
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateAll()
+	}
+
+	return newExperimentDto(experiment), nil
+}
+
+func newExperimentDto(experiment *entities.Experiment) *dtos.ExperimentDto {
+	return &dtos.ExperimentDto{
+		ID:               experiment.ID,
+		OfferID:          experiment.OfferID,
+		Salt:             experiment.Salt,
+		ControlPercent:   experiment.ControlPercent,
+		TreatmentPercent: experiment.TreatmentPercent,
+	}
+}
@@ -1,32 +1,353 @@
 package use_cases
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/Drinnn/eligible-offers-api/internal/dtos"
 	"github.com/Drinnn/eligible-offers-api/internal/entities"
 	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
 	"github.com/Drinnn/eligible-offers-api/internal/repositories"
 )
 
+var (
+	mccCodePattern  = regexp.MustCompile(`^\d{4}$`)
+	mccRangePattern = regexp.MustCompile(`^(\d{4})-(\d{4})$`)
+)
+
 type UpsertOfferUseCase struct {
-	offerRepository repositories.OfferRepository
+	offerRepository              repositories.OfferRepository
+	mccRepository                repositories.MCCRepository
+	segmentRepository            repositories.SegmentRepository
+	transactionRepository        repositories.TransactionRepository
+	offerMatchRepository         repositories.OfferMatchRepository
+	eligibilityCache             EligibilityCacheInvalidator // optional
+	reevaluateEligibilityUseCase *ReevaluateEligibilityUseCase
+	segmentLock                  *sync.RWMutex
+	offerMatchLock               *sync.RWMutex
+	validator                    *offerValidator
+}
+
+// UpsertOfferResult is the offer as stored, or as it would be stored when the request only
+// validates it, with the problems found by rules set to warn.
+type UpsertOfferResult struct {
+	Offer    *entities.Offer
+	Warnings map[string]customErrors.FieldMessage
 }
 
-func NewUpsertOfferUseCase(offerRepository repositories.OfferRepository) *UpsertOfferUseCase {
+type UpsertOfferDependencies struct {
+	OfferRepository              repositories.OfferRepository
+	MCCRepository                repositories.MCCRepository
+	SegmentRepository            repositories.SegmentRepository
+	TransactionRepository        repositories.TransactionRepository
+	OfferMatchRepository         repositories.OfferMatchRepository
+	EligibilityCache             EligibilityCacheInvalidator // optional
+	ReevaluateEligibilityUseCase *ReevaluateEligibilityUseCase
+	SegmentLock                  *sync.RWMutex // shared with DeleteSegmentUseCase
+	OfferMatchLock               *sync.RWMutex // shared with IngestTransactionsUseCase
+	Rules                        OfferRules
+}
+
+func NewUpsertOfferUseCase(deps UpsertOfferDependencies) *UpsertOfferUseCase {
 	return &UpsertOfferUseCase{
-		offerRepository: offerRepository,
+		offerRepository:              deps.OfferRepository,
+		mccRepository:                deps.MCCRepository,
+		segmentRepository:            deps.SegmentRepository,
+		transactionRepository:        deps.TransactionRepository,
+		offerMatchRepository:         deps.OfferMatchRepository,
+		eligibilityCache:             deps.EligibilityCache,
+		reevaluateEligibilityUseCase: deps.ReevaluateEligibilityUseCase,
+		segmentLock:                  deps.SegmentLock,
+		offerMatchLock:               deps.OfferMatchLock,
+		validator: &offerValidator{
+			rules:                 deps.Rules,
+			offerRepository:       deps.OfferRepository,
+			transactionRepository: deps.TransactionRepository,
+		},
 	}
 }
 
-func (u *UpsertOfferUseCase) Execute(request *dtos.UpsertOfferRequest) (*entities.Offer, error) {
+func (u *UpsertOfferUseCase) Execute(ctx context.Context, request *dtos.UpsertOfferRequest) (*UpsertOfferResult, error) {
 	if !request.StartsAt.Before(request.EndsAt) {
-		return nil, customErrors.NewBadRequestError("starts_at must be before ends_at", nil)
+		return nil, customErrors.NewValidationError("starts_at must be before ends_at", nil)
+	}
+
+	windowType := entities.WindowType(request.WindowType)
+	if windowType == "" {
+		windowType = entities.WindowRolling
+	}
+	if windowType == entities.WindowRolling && request.LookbackDays == 0 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+			"lookback_days": customErrors.NewFieldMessage("lookback_days_required", "lookback_days is required for rolling windows"),
+		})
+	}
+
+	var location *time.Location
+	if request.Timezone != "" {
+		loaded, err := time.LoadLocation(request.Timezone)
+		if err != nil {
+			return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+				"timezone": customErrors.NewFieldMessage("unknown_timezone", "unknown timezone {0}", request.Timezone),
+			})
+		}
+		location = loaded
+	}
+
+	mccCodes, err := u.expandMCCWhitelist(ctx, request.MCCWhitelist)
+	if err != nil {
+		return nil, err
+	}
+	mccWhitelist := slices.Compact(slices.Sorted(slices.Values(slices.Concat(mccCodes...))))
+
+	// Keep the segments the offer references from being deleted until it is saved.
+	u.segmentLock.RLock()
+	defer u.segmentLock.RUnlock()
+
+	if err := u.checkSegmentsExist(ctx, request.IncludeSegments, request.ExcludeSegments); err != nil {
+		return nil, err
+	}
+
+	predicates, err := newAttributePredicates(request.AttributePredicates)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := entities.NewOffer(request.ID, request.MerchantID, mccWhitelist, request.Active, request.MinTxnCount, request.LookbackDays, request.StartsAt, request.EndsAt)
+
+	offer.MCCSelectors = slices.Clone(request.MCCWhitelist)
+	offer.WindowType = windowType
+	offer.Location = location
+	offer.MinDistinctMerchants = request.MinDistinctMerchants
+	offer.SingleUse = request.SingleUse
+	offer.MaxRedemptions = request.MaxRedemptions
+	offer.BudgetCents = request.BudgetCents
+	offer.IncludeSegments = request.IncludeSegments
+	offer.ExcludeSegments = request.ExcludeSegments
+	offer.AttributePredicates = predicates
+	if request.Streak != nil {
+		offer.Streak = &entities.Streak{
+			Period: entities.StreakPeriod(request.Streak.Period),
+			Count:  request.Streak.Count,
+		}
+	}
+
+	if request.Schedule != nil {
+		schedule, err := newSchedule(request.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		offer.Schedule = schedule
+	}
+
+	warnings, err := u.validator.validate(ctx, request, offer, mccCodes)
+	if err != nil {
+		return nil, err
+	}
+	if request.ValidateOnly {
+		return &UpsertOfferResult{Offer: offer, Warnings: warnings}, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The offer's matches must be rebuilt once it is stored, even if the request is cancelled.
+	ctx = context.WithoutCancel(ctx)
+	affectedUserIDs, err := u.saveOffer(ctx, offer)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateAll()
+	}
+
+	// The offer is already stored, so a failed re-evaluation is logged rather than failing the
+	// request; the transitions are picked up the next time the users are re-evaluated.
+	if _, err := u.reevaluateEligibilityUseCase.Execute(ctx, &dtos.ReevaluateEligibilityRequest{UserIDs: affectedUserIDs, Now: request.Now}); err != nil {
+		slog.ErrorContext(ctx, "offer re-evaluation failed", slog.String("offer_id", offer.ID), slog.Any("error", err))
+	}
+
+	return &UpsertOfferResult{Offer: offer, Warnings: warnings}, nil
+}
+
+// saveOffer stores the offer and rebuilds its matches, since merchant, MCC and schedule
+// changes can alter which transactions match. Ingestion is held off meanwhile: matches it
+// added for transactions missing from the snapshot would be dropped by ReplaceOffer.
+//
+// It returns the users with transactions matching the previous or the new version of the
+// offer. An offer needs at least one matching transaction, so only they can gain or lose it.
+func (u *UpsertOfferUseCase) saveOffer(ctx context.Context, offer *entities.Offer) ([]string, error) {
+	u.offerMatchLock.Lock()
+	defer u.offerMatchLock.Unlock()
+
+	previous, err := u.offerRepository.GetByID(ctx, offer.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, customErrors.NewServiceError("failed to get offer", err)
+	}
+
+	if err := u.offerRepository.Upsert(ctx, offer); err != nil {
+		return nil, customErrors.NewServiceError("failed to upsert offer", err)
+	}
+
+	transactions, err := u.transactionRepository.GetAll(ctx)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to get transactions", err)
+	}
+	matched := make([]*entities.Transaction, 0)
+	affected := make(map[string]bool)
+	affectedUserIDs := make([]string, 0)
+	for _, transaction := range transactions {
+		matchesOffer := offer.Matches(transaction)
+		if matchesOffer {
+			matched = append(matched, transaction)
+		}
+		if (matchesOffer || (previous != nil && previous.Matches(transaction))) && !affected[transaction.UserID] {
+			affected[transaction.UserID] = true
+			affectedUserIDs = append(affectedUserIDs, transaction.UserID)
+		}
+	}
+	if err := u.offerMatchRepository.ReplaceOffer(ctx, offer, matched); err != nil {
+		return nil, customErrors.NewServiceError("failed to rebuild offer matches", err)
+	}
+	return affectedUserIDs, nil
+}
+
+// expandMCCWhitelist resolves exact codes, ranges ("5812-5814") and category
+// names ("restaurants") against the MCC catalog into the codes of each selector.
+func (u *UpsertOfferUseCase) expandMCCWhitelist(ctx context.Context, selectors []string) ([][]string, error) {
+	codes := make([][]string, len(selectors))
+	fieldErrors := make(map[string]customErrors.FieldMessage)
+
+	for i, selector := range selectors {
+		field := fmt.Sprintf("mcc_whitelist[%d]", i)
+		selector = strings.TrimSpace(selector)
+
+		switch {
+		case mccCodePattern.MatchString(selector):
+			_, err := u.mccRepository.GetByCode(ctx, selector)
+			if errors.Is(err, repositories.ErrNotFound) {
+				fieldErrors[field] = customErrors.NewFieldMessage("unknown_mcc", "unknown mcc {0}", selector)
+				continue
+			}
+			if err != nil {
+				return nil, customErrors.NewServiceError("failed to get mcc", err)
+			}
+			codes[i] = []string{selector}
+
+		case mccRangePattern.MatchString(selector):
+			bounds := mccRangePattern.FindStringSubmatch(selector)
+			from, to := bounds[1], bounds[2]
+			if from > to {
+				fieldErrors[field] = customErrors.NewFieldMessage("mcc_range_reversed", "mcc range start must not be greater than its end")
+				continue
+			}
+
+			mccs, err := u.mccRepository.GetAll(ctx)
+			if err != nil {
+				return nil, customErrors.NewServiceError("failed to get mccs", err)
+			}
+			for _, mcc := range mccs {
+				if mcc.Code >= from && mcc.Code <= to {
+					codes[i] = append(codes[i], mcc.Code)
+				}
+			}
+			if len(codes[i]) == 0 {
+				fieldErrors[field] = customErrors.NewFieldMessage("mcc_range_empty", "mcc range {0} matches no known mccs", selector)
+			}
+
+		default:
+			mccs, err := u.mccRepository.GetByCategory(ctx, selector)
+			if errors.Is(err, repositories.ErrNotFound) {
+				fieldErrors[field] = customErrors.NewFieldMessage("invalid_mcc_selector", "must be a 4-digit mcc, an mcc range or a known mcc category")
+				continue
+			}
+			if err != nil {
+				return nil, customErrors.NewServiceError("failed to get mccs", err)
+			}
+			for _, mcc := range mccs {
+				codes[i] = append(codes[i], mcc.Code)
+			}
+		}
 	}
 
-	offer := entities.NewOffer(request.ID, request.MerchantID, request.MCCWhitelist, request.Active, request.MinTxnCount, request.LookbackDays, request.StartsAt, request.EndsAt)
+	if len(fieldErrors) > 0 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", fieldErrors)
+	}
+	return codes, nil
+}
+
+func (u *UpsertOfferUseCase) checkSegmentsExist(ctx context.Context, includeSegments, excludeSegments []string) error {
+	fieldErrors := make(map[string]customErrors.FieldMessage)
+
+	for field, segmentIDs := range map[string][]string{"include_segments": includeSegments, "exclude_segments": excludeSegments} {
+		for i, segmentID := range segmentIDs {
+			_, err := u.segmentRepository.GetByID(ctx, segmentID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				fieldErrors[fmt.Sprintf("%s[%d]", field, i)] = customErrors.NewFieldMessage("unknown_segment", "unknown segment {0}", segmentID)
+				continue
+			}
+			if err != nil {
+				return customErrors.NewServiceError("failed to get segment", err)
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return customErrors.NewFieldValidationError("Invalid request body", fieldErrors)
+	}
+	return nil
+}
+
+func newAttributePredicates(requests []dtos.AttributePredicateDto) ([]*entities.AttributePredicate, error) {
+	predicates := make([]*entities.AttributePredicate, 0, len(requests))
+	fieldErrors := make(map[string]customErrors.FieldMessage)
 
-	if err := u.offerRepository.Upsert(offer); err != nil {
-		return nil, customErrors.NewServiceError("failed to upsert offer")
+	for i, request := range requests {
+		predicate, err := entities.NewAttributePredicate(request.Attribute, request.Operator, request.Values)
+		if err != nil {
+			fieldErrors[fmt.Sprintf("attribute_predicates[%d]", i)] = fieldMessage(err)
+			continue
+		}
+		predicates = append(predicates, predicate)
 	}
 
-	return offer, nil
+	if len(fieldErrors) > 0 {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", fieldErrors)
+	}
+	return predicates, nil
+}
+
+func newSchedule(request *dtos.ScheduleDto) (*entities.Schedule, error) {
+	days := make([]time.Weekday, 0, len(request.Days))
+	for _, name := range request.Days {
+		day, _ := entities.ParseWeekday(name)
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	schedule, err := entities.NewSchedule(request.Timezone, days, request.StartTime, request.EndTime, request.ApplyToTransactions)
+	if err != nil {
+		return nil, customErrors.NewFieldValidationError("Invalid request body", map[string]customErrors.FieldMessage{
+			"schedule": fieldMessage(err),
+		})
+	}
+	return schedule, nil
+}
+
+// fieldMessage returns the translatable message of an entity error, or its text if it has none.
+func fieldMessage(err error) customErrors.FieldMessage {
+	var message customErrors.FieldMessage
+	if errors.As(err, &message) {
+		return message
+	}
+	return customErrors.FieldMessage{Text: err.Error()}
 }
@@ -0,0 +1,260 @@
+package use_cases_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+)
+
+func newUpsertOfferUseCase(t *testing.T) *use_cases.UpsertOfferUseCase {
+	t.Helper()
+
+	useCase, _ := newUpsertOfferUseCaseWithRules(t, use_cases.OfferRules{})
+	return useCase
+}
+
+func newUpsertOfferUseCaseWithRules(t *testing.T, rules use_cases.OfferRules) (*use_cases.UpsertOfferUseCase, *repositories.InMemoryOfferRepository) {
+	t.Helper()
+
+	mccRepo, err := repositories.NewEmbeddedMCCRepository()
+	if err != nil {
+		t.Fatalf("failed to load mcc catalog: %v", err)
+	}
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	txnRepo := repositories.NewInMemoryTransactionRepository()
+	matchRepo := repositories.NewInMemoryOfferMatchRepository()
+	reevaluateUseCase := newReevaluateEligibilityUseCase(offerRepo, txnRepo, matchRepo, repositories.NewInMemoryOutboxRepository())
+	return use_cases.NewUpsertOfferUseCase(use_cases.UpsertOfferDependencies{
+		OfferRepository:              offerRepo,
+		MCCRepository:                mccRepo,
+		SegmentRepository:            repositories.NewInMemorySegmentRepository(),
+		TransactionRepository:        txnRepo,
+		OfferMatchRepository:         matchRepo,
+		ReevaluateEligibilityUseCase: reevaluateUseCase,
+		SegmentLock:                  &sync.RWMutex{},
+		OfferMatchLock:               &sync.RWMutex{},
+		Rules:                        rules,
+	}), offerRepo
+}
+
+func newUpsertOfferRequest(mccWhitelist ...string) *dtos.UpsertOfferRequest {
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	return &dtos.UpsertOfferRequest{
+		MerchantID:   "merchant-1",
+		MCCWhitelist: mccWhitelist,
+		Active:       true,
+		MinTxnCount:  1,
+		LookbackDays: 30,
+		StartsAt:     now,
+		EndsAt:       now.AddDate(0, 1, 0),
+	}
+}
+
+func TestUpsertOffer_ExpandsMCCRangesAndCategories(t *testing.T) {
+	useCase := newUpsertOfferUseCase(t)
+
+	// Given: A whitelist mixing an exact code, a range and a category
+	request := newUpsertOfferRequest("5411", "5812-5814", "Gas_Stations", "5812")
+
+	// When: We upsert the offer
+	result, err := useCase.Execute(t.Context(), request)
+
+	// Then: The whitelist is expanded, deduplicated and sorted
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{"5411", "5541", "5542", "5812", "5813", "5814", "5983"}
+	if !slices.Equal(result.Offer.MCCWhitelist, expected) {
+		t.Errorf("expected %v, got %v", expected, result.Offer.MCCWhitelist)
+	}
+
+	// And: The selectors are kept as sent
+	if !slices.Equal(result.Offer.MCCSelectors, request.MCCWhitelist) {
+		t.Errorf("expected selectors %v, got %v", request.MCCWhitelist, result.Offer.MCCSelectors)
+	}
+}
+
+func TestUpsertOffer_ExpandsCatalogRangeEntries(t *testing.T) {
+	useCase := newUpsertOfferUseCase(t)
+
+	// Given: The airlines category, which is defined as a range in the catalog
+	request := newUpsertOfferRequest("airlines")
+
+	// When: We upsert the offer
+	result, err := useCase.Execute(t.Context(), request)
+
+	// Then: Every airline code is whitelisted
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Offer.MCCWhitelist) != 301 {
+		t.Errorf("expected 301 airline mccs, got %d", len(result.Offer.MCCWhitelist))
+	}
+	if !slices.Contains(result.Offer.MCCWhitelist, "3000") || !slices.Contains(result.Offer.MCCWhitelist, "4511") {
+		t.Errorf("expected 3000 and 4511 to be whitelisted, got %v", result.Offer.MCCWhitelist)
+	}
+}
+
+func TestUpsertOffer_RejectsUnknownMCCSelectors(t *testing.T) {
+	useCase := newUpsertOfferUseCase(t)
+
+	// Given: An unknown code, an inverted range, an empty range and an unknown category
+	request := newUpsertOfferRequest("0001", "5814-5812", "0100-0200", "casinos")
+
+	// When: We upsert the offer
+	_, err := useCase.Execute(t.Context(), request)
+
+	// Then: Every invalid entry is reported by index
+	var validationErr *customErrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	for _, field := range []string{"mcc_whitelist[0]", "mcc_whitelist[1]", "mcc_whitelist[2]", "mcc_whitelist[3]"} {
+		if _, ok := validationErr.Errors[field]; !ok {
+			t.Errorf("expected error for %s, got %v", field, validationErr.Errors)
+		}
+	}
+}
+
+func TestUpsertOffer_RejectsInvalidAttributePredicates(t *testing.T) {
+	useCase := newUpsertOfferUseCase(t)
+
+	// Given: Predicates with an unknown attribute and an operator/attribute mismatch
+	request := newUpsertOfferRequest("5812")
+	request.AttributePredicates = []dtos.AttributePredicateDto{
+		{Attribute: "card_tier", Operator: "in", Values: []string{"gold"}},
+		{Attribute: "favorite_color", Operator: "eq", Values: []string{"blue"}},
+		{Attribute: "country", Operator: "within_days", Values: []string{"90"}},
+	}
+
+	// When: We upsert the offer
+	_, err := useCase.Execute(t.Context(), request)
+
+	// Then: Only the invalid predicates are reported
+	var validationErr *customErrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Errors) != 2 {
+		t.Errorf("expected 2 errors, got %v", validationErr.Errors)
+	}
+	if _, ok := validationErr.Errors["attribute_predicates[0]"]; ok {
+		t.Errorf("expected attribute_predicates[0] to be valid, got %v", validationErr.Errors)
+	}
+}
+
+func TestUpsertOffer_OfferRulesRejectOrWarnBySeverity(t *testing.T) {
+	rules := use_cases.NewOfferRules(365, 2, map[string]string{
+		"ends_at_in_past":         "error",
+		"max_lookback_days":       "error",
+		"reachable_min_txn_count": "error",
+		"duplicate_mccs":          "warning",
+		"unknown_merchant":        "warning",
+	})
+	useCase, offerRepo := newUpsertOfferUseCaseWithRules(t, rules)
+
+	// Given: An offer breaking every error rule
+	request := newUpsertOfferRequest("5812", "5411", "5812")
+	request.LookbackDays = 400
+	request.MinTxnCount = 1000
+	request.Now = request.EndsAt.AddDate(0, 0, 1)
+
+	// When: We upsert the offer
+	_, err := useCase.Execute(t.Context(), request)
+
+	// Then: Every error rule is reported by field and the offer is not saved
+	var validationErr *customErrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	for _, field := range []string{"ends_at", "lookback_days", "min_txn_count"} {
+		if _, ok := validationErr.Errors[field]; !ok {
+			t.Errorf("expected error for %s, got %v", field, validationErr.Errors)
+		}
+	}
+	if _, ok := validationErr.Errors["mcc_whitelist[2]"]; ok {
+		t.Errorf("expected warning rules not to reject the offer, got %v", validationErr.Errors)
+	}
+	if offers, _ := offerRepo.GetAll(t.Context()); len(offers) != 0 {
+		t.Errorf("expected no offer to be saved, got %d", len(offers))
+	}
+
+	// When: The offer only breaks warning rules
+	request = newUpsertOfferRequest("5812", "5411", " 5812 ")
+	result, err := useCase.Execute(t.Context(), request)
+
+	// Then: It is saved and the warnings are returned
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Warnings["mcc_whitelist[2]"].Text != "overlaps mcc_whitelist[0] (5812) on mccs 5812" {
+		t.Errorf("expected a duplicate mcc warning, got %v", result.Warnings)
+	}
+	if _, ok := result.Warnings["merchant_id"]; !ok {
+		t.Errorf("expected an unknown merchant warning, got %v", result.Warnings)
+	}
+	if offers, _ := offerRepo.GetAll(t.Context()); len(offers) != 1 {
+		t.Errorf("expected the offer to be saved, got %d offers", len(offers))
+	}
+}
+
+func TestUpsertOffer_DuplicateMCCRuleComparesExpandedCodes(t *testing.T) {
+	useCase, _ := newUpsertOfferUseCaseWithRules(t, use_cases.NewOfferRules(365, 10, map[string]string{"duplicate_mccs": "warning"}))
+
+	// Given: A range, a category and an exact code covering the same restaurant mccs
+	request := newUpsertOfferRequest("5810-5815", "5411", "Restaurants", "5812")
+
+	// When: We upsert the offer
+	result, err := useCase.Execute(t.Context(), request)
+
+	// Then: Each selector overlapping an earlier one names it and the codes they share
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]string{
+		"mcc_whitelist[2]": "overlaps mcc_whitelist[0] (5810-5815) on mccs 5812, 5813, 5814",
+		"mcc_whitelist[3]": "overlaps mcc_whitelist[0] (5810-5815) on mccs 5812",
+	}
+	if len(result.Warnings) != len(expected) {
+		t.Errorf("expected warnings %v, got %v", expected, result.Warnings)
+	}
+	for field, message := range expected {
+		if result.Warnings[field].Text != message {
+			t.Errorf("expected %s to warn %q, got %q", field, message, result.Warnings[field].Text)
+		}
+	}
+}
+
+func TestUpsertOffer_ValidateOnlyDoesNotSave(t *testing.T) {
+	useCase, offerRepo := newUpsertOfferUseCaseWithRules(t, use_cases.NewOfferRules(365, 10, map[string]string{"unknown_merchant": "warning"}))
+
+	// Given: A valid offer for a merchant nothing refers to yet
+	request := newUpsertOfferRequest("restaurants")
+	request.ValidateOnly = true
+
+	// When: We only validate it
+	result, err := useCase.Execute(t.Context(), request)
+
+	// Then: The offer is resolved and checked as if it were saved
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Offer.MCCWhitelist) == 0 {
+		t.Errorf("expected the whitelist to be expanded")
+	}
+	if _, ok := result.Warnings["merchant_id"]; !ok {
+		t.Errorf("expected an unknown merchant warning, got %v", result.Warnings)
+	}
+
+	// And: Nothing is stored
+	if offers, _ := offerRepo.GetAll(t.Context()); len(offers) != 0 {
+		t.Errorf("expected no offer to be saved, got %d", len(offers))
+	}
+}
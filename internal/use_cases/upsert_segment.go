@@ -0,0 +1,52 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type UpsertSegmentUseCase struct {
+	segmentRepository repositories.SegmentRepository
+	eligibilityCache  EligibilityCacheInvalidator // optional
+}
+
+func NewUpsertSegmentUseCase(segmentRepository repositories.SegmentRepository, eligibilityCache EligibilityCacheInvalidator) *UpsertSegmentUseCase {
+	return &UpsertSegmentUseCase{
+		segmentRepository: segmentRepository,
+		eligibilityCache:  eligibilityCache,
+	}
+}
+
+func (u *UpsertSegmentUseCase) Execute(ctx context.Context, request *dtos.UpsertSegmentRequest) (*dtos.SegmentDto, error) {
+	segment := entities.NewSegment(request.ID, request.Name, request.Description)
+
+	if err := u.segmentRepository.Upsert(ctx, segment); err != nil {
+		return nil, customErrors.NewServiceError("failed to upsert segment", err)
+	}
+
+	if request.UserIDs != nil {
+		if err := u.segmentRepository.ReplaceMembers(ctx, segment.ID, request.UserIDs); err != nil {
+			return nil, customErrors.NewServiceError("failed to set segment members", err)
+		}
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateAll()
+	}
+
+	memberCount, err := u.segmentRepository.CountMembers(ctx, segment.ID)
+	if err != nil {
+		return nil, customErrors.NewServiceError("failed to count segment members", err)
+	}
+
+	return &dtos.SegmentDto{
+		ID:          segment.ID,
+		Name:        segment.Name,
+		Description: segment.Description,
+		MemberCount: memberCount,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type UpsertUserProfileUseCase struct {
+	userRepository   repositories.UserRepository
+	eligibilityCache EligibilityCacheInvalidator // optional
+}
+
+func NewUpsertUserProfileUseCase(userRepository repositories.UserRepository, eligibilityCache EligibilityCacheInvalidator) *UpsertUserProfileUseCase {
+	return &UpsertUserProfileUseCase{
+		userRepository:   userRepository,
+		eligibilityCache: eligibilityCache,
+	}
+}
+
+func (u *UpsertUserProfileUseCase) Execute(ctx context.Context, request *dtos.UpsertUserProfileRequest) (*dtos.UserProfileDto, error) {
+	user := entities.NewUser(request.ID, request.Country, request.SignupDate, request.CardTier, request.Tags)
+
+	if err := u.userRepository.Upsert(ctx, user); err != nil {
+		return nil, customErrors.NewServiceError("failed to upsert user profile", err)
+	}
+
+	if u.eligibilityCache != nil {
+		u.eligibilityCache.InvalidateUsers([]string{user.ID})
+	}
+
+	return newUserProfileDto(user), nil
+}
+
+func newUserProfileDto(user *entities.User) *dtos.UserProfileDto {
+	profile := &dtos.UserProfileDto{
+		ID:       user.ID,
+		Country:  user.Country,
+		CardTier: user.CardTier,
+		Tags:     user.Tags,
+	}
+	if !user.SignupDate.IsZero() {
+		profile.SignupDate = &user.SignupDate
+	}
+	return profile
+}
@@ -0,0 +1,51 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	customErrors "github.com/Drinnn/eligible-offers-api/internal/errors"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+type UpsertWebhookSubscriptionUseCase struct {
+	subscriptionRepository repositories.WebhookSubscriptionRepository
+}
+
+func NewUpsertWebhookSubscriptionUseCase(subscriptionRepository repositories.WebhookSubscriptionRepository) *UpsertWebhookSubscriptionUseCase {
+	return &UpsertWebhookSubscriptionUseCase{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+func (u *UpsertWebhookSubscriptionUseCase) Execute(ctx context.Context, request *dtos.UpsertWebhookSubscriptionRequest) (*dtos.WebhookSubscriptionDto, error) {
+	eventTypes := make([]entities.OfferEventType, len(request.EventTypes))
+	for i, eventType := range request.EventTypes {
+		eventTypes[i] = entities.OfferEventType(eventType)
+	}
+
+	subscription := entities.NewWebhookSubscription(request.ID, request.MerchantID, request.URL, request.Secret, eventTypes)
+	if err := u.subscriptionRepository.Upsert(ctx, subscription); err != nil {
+		return nil, customErrors.NewServiceError("failed to upsert webhook subscription", err)
+	}
+
+	response := newWebhookSubscriptionDto(subscription)
+	response.Secret = subscription.Secret
+
+	return &response, nil
+}
+
+func newWebhookSubscriptionDto(subscription *entities.WebhookSubscription) dtos.WebhookSubscriptionDto {
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return dtos.WebhookSubscriptionDto{
+		ID:         subscription.ID,
+		MerchantID: subscription.MerchantID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+	}
+}
@@ -0,0 +1,85 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/sinks"
+)
+
+// OutboxDispatcher polls the outbox and delivers pending events to every sink. Events are
+// only marked dispatched once all sinks accept the batch, so a failing sink causes the
+// batch to be retried on the next tick (and redelivered to sinks that already accepted it).
+type OutboxDispatcher struct {
+	outboxRepository repositories.OutboxRepository
+	sinks            []sinks.Sink
+	interval         time.Duration
+	batchSize        int
+}
+
+func NewOutboxDispatcher(outboxRepository repositories.OutboxRepository, eventSinks []sinks.Sink, interval time.Duration, batchSize int) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxRepository: outboxRepository,
+		sinks:            eventSinks,
+		interval:         interval,
+		batchSize:        batchSize,
+	}
+}
+
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DispatchPending(ctx, time.Now()); err != nil {
+				slog.ErrorContext(ctx, "outbox dispatch failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// DispatchPending delivers pending events in batches until the outbox is drained or a sink fails.
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context, now time.Time) (int, error) {
+	dispatched := 0
+	for {
+		events, err := d.outboxRepository.GetPending(ctx, d.batchSize)
+		if err != nil {
+			return dispatched, fmt.Errorf("get pending events: %w", err)
+		}
+		if len(events) == 0 {
+			return dispatched, nil
+		}
+
+		batch := &dtos.OfferEventBatchDto{Events: make([]dtos.OfferEventDto, len(events))}
+		eventIDs := make([]string, len(events))
+		for i, event := range events {
+			batch.Events[i] = dtos.OfferEventDto{
+				ID:         event.ID,
+				Type:       string(event.Type),
+				UserID:     event.UserID,
+				OfferID:    event.OfferID,
+				OccurredAt: event.OccurredAt,
+			}
+			eventIDs[i] = event.ID
+		}
+
+		for _, sink := range d.sinks {
+			if err := sink.Send(ctx, batch); err != nil {
+				return dispatched, fmt.Errorf("send to %s: %w", sink.Name(), err)
+			}
+		}
+
+		if err := d.outboxRepository.MarkDispatched(ctx, eventIDs, now); err != nil {
+			return dispatched, fmt.Errorf("mark events dispatched: %w", err)
+		}
+		dispatched += len(events)
+	}
+}
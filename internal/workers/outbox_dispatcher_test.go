@@ -0,0 +1,136 @@
+package workers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/sinks"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/Drinnn/eligible-offers-api/internal/workers"
+)
+
+func TestOutboxDispatcher_DeliversDurableEventsToSinks(t *testing.T) {
+	dir := t.TempDir()
+	outboxPath := filepath.Join(dir, "outbox.jsonl")
+	sinkPath := filepath.Join(dir, "events.jsonl")
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	// Given: Two events written to a file outbox before a restart
+	outboxRepo, err := repositories.NewFileOutboxRepository(outboxPath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	outboxRepo.Append(t.Context(), []*entities.OfferEvent{
+		entities.NewOfferEvent(entities.EligibilityGained, "user-1", "offer-1", now),
+		entities.NewOfferEvent(entities.EligibilityLost, "user-2", "offer-1", now),
+	})
+	outboxRepo.Close()
+
+	outboxRepo, err = repositories.NewFileOutboxRepository(outboxPath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer outboxRepo.Close()
+
+	// And: An HTTP sink that fails the first delivery
+	received := make([]dtos.OfferEventBatchDto, 0)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch dtos.OfferEventBatchDto
+		json.NewDecoder(r.Body).Decode(&batch)
+		received = append(received, batch)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher := workers.NewOutboxDispatcher(outboxRepo, []sinks.Sink{sinks.NewFileSink(sinkPath), sinks.NewHTTPSink(server.URL, time.Second)}, time.Second, 10)
+
+	// When: The dispatcher runs and the HTTP sink fails
+	_, err = dispatcher.DispatchPending(t.Context(), now)
+
+	// Then: The events stay pending
+	if err == nil {
+		t.Fatalf("expected an error from the failing sink")
+	}
+	if pending, _ := outboxRepo.GetPending(t.Context(), 10); len(pending) != 2 {
+		t.Fatalf("expected 2 pending events, got %d", len(pending))
+	}
+
+	// When: The dispatcher retries
+	dispatched, err := dispatcher.DispatchPending(t.Context(), now)
+
+	// Then: Both sinks receive the events and the outbox is drained
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dispatched != 2 {
+		t.Errorf("expected 2 dispatched events, got %d", dispatched)
+	}
+	if len(received) != 1 || len(received[0].Events) != 2 || received[0].Events[0].Type != "eligibility.gained" {
+		t.Errorf("expected the HTTP sink to receive both events in order, got %+v", received)
+	}
+	content, _ := os.ReadFile(sinkPath)
+	if lines := strings.Count(string(content), "\n"); lines != 4 {
+		t.Errorf("expected 4 lines in the file sink (delivered twice), got %d", lines)
+	}
+
+	// And: Dispatched events are not replayed after another restart
+	outboxRepo.Close()
+	outboxRepo, err = repositories.NewFileOutboxRepository(outboxPath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pending, _ := outboxRepo.GetPending(t.Context(), 10); len(pending) != 0 {
+		t.Errorf("expected 0 pending events after restart, got %d", len(pending))
+	}
+	outboxRepo.Close()
+}
+
+func TestOutboxDispatcher_RetriedBatchesEnqueueWebhooksOnce(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	subscriptionRepo := repositories.NewInMemoryWebhookSubscriptionRepository()
+	deliveryRepo := repositories.NewInMemoryWebhookDeliveryRepository()
+	outboxRepo := repositories.NewInMemoryOutboxRepository()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	// Given: A pending event for an offer whose merchant has a webhook subscription
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true})
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-1", "merchant-1", "http://example.com/hook", "top-secret", nil))
+	outboxRepo.Append(t.Context(), []*entities.OfferEvent{entities.NewOfferEvent(entities.EligibilityGained, "user-1", "offer-1", now)})
+
+	// And: An HTTP sink after the webhook sink that keeps failing
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhookSink := sinks.NewWebhookSink(use_cases.NewEnqueueWebhookDeliveriesUseCase(offerRepo, subscriptionRepo, deliveryRepo))
+	dispatcher := workers.NewOutboxDispatcher(outboxRepo, []sinks.Sink{webhookSink, sinks.NewHTTPSink(server.URL, time.Second)}, time.Second, 10)
+
+	// When: The dispatcher retries the batch several times
+	for range 3 {
+		if _, err := dispatcher.DispatchPending(t.Context(), now); err == nil {
+			t.Fatalf("expected an error from the failing sink")
+		}
+	}
+
+	// Then: The subscription has a single delivery for the event
+	deliveries, _ := deliveryRepo.GetByStatus(t.Context(), entities.WebhookDeliveryPending)
+	if len(deliveries) != 1 {
+		t.Errorf("expected 1 webhook delivery, got %d", len(deliveries))
+	}
+}
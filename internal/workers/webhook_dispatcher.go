@@ -0,0 +1,135 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+)
+
+// WebhookDispatcher attempts due webhook deliveries, rescheduling failures with exponential
+// backoff and dead-lettering them after maxAttempts. A missing subscription is a failed
+// attempt like any other, so deliveries are not dropped while subscriptions are unavailable.
+type WebhookDispatcher struct {
+	deliveryRepository     repositories.WebhookDeliveryRepository
+	subscriptionRepository repositories.WebhookSubscriptionRepository
+	client                 *http.Client
+	maxAttempts            int
+	baseBackoff            time.Duration
+	maxBackoff             time.Duration
+	interval               time.Duration
+	batchSize              int
+}
+
+func NewWebhookDispatcher(deliveryRepository repositories.WebhookDeliveryRepository, subscriptionRepository repositories.WebhookSubscriptionRepository, client *http.Client, maxAttempts int, baseBackoff, maxBackoff, interval time.Duration, batchSize int) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		deliveryRepository:     deliveryRepository,
+		subscriptionRepository: subscriptionRepository,
+		client:                 client,
+		maxAttempts:            maxAttempts,
+		baseBackoff:            baseBackoff,
+		maxBackoff:             maxBackoff,
+		interval:               interval,
+		batchSize:              batchSize,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DeliverDue(ctx, time.Now()); err != nil {
+				slog.ErrorContext(ctx, "webhook dispatch failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// DeliverDue makes one attempt at every delivery due at now and returns how many succeeded.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := d.deliveryRepository.GetDue(ctx, now, d.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get due deliveries: %w", err)
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		statusCode, err := d.attempt(ctx, delivery, now)
+		if ctx.Err() != nil {
+			// An attempt interrupted by shutdown does not count against the delivery.
+			return delivered, ctx.Err()
+		}
+
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		switch {
+		case err == nil:
+			delivery.Status = entities.WebhookDeliveryDelivered
+			delivery.DeliveredAt = &now
+			delivery.LastError = ""
+			delivered++
+		case delivery.Attempts >= d.maxAttempts:
+			delivery.Status = entities.WebhookDeliveryDeadLettered
+			delivery.LastError = err.Error()
+		default:
+			delivery.NextAttemptAt = now.Add(entities.RetryBackoff(delivery.Attempts, d.baseBackoff, d.maxBackoff))
+			delivery.LastError = err.Error()
+		}
+
+		if err := d.deliveryRepository.Update(ctx, delivery); err != nil {
+			return delivered, fmt.Errorf("update delivery %s: %w", delivery.ID, err)
+		}
+	}
+
+	return delivered, nil
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *entities.WebhookDelivery, now time.Time) (int, error) {
+	subscription, err := d.subscriptionRepository.GetByID(ctx, delivery.SubscriptionID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return 0, fmt.Errorf("subscription %s not found: %w", delivery.SubscriptionID, err)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get subscription: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set(WebhookSignatureHeader, "sha256="+subscription.Sign(now, delivery.Payload))
+
+	resp, err := d.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,276 @@
+package workers_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/dtos"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/sinks"
+	"github.com/Drinnn/eligible-offers-api/internal/use_cases"
+	"github.com/Drinnn/eligible-offers-api/internal/workers"
+)
+
+func TestWebhookDispatcher_SignsRetriesAndDeadLetters(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	subscriptionRepo := repositories.NewInMemoryWebhookSubscriptionRepository()
+	deliveryRepo := repositories.NewInMemoryWebhookDeliveryRepository()
+
+	// Given: A merchant endpoint that verifies signatures and answers with a configurable status
+	var mu sync.Mutex
+	status := http.StatusInternalServerError
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("top-secret"))
+		mac.Write([]byte(r.Header.Get(workers.WebhookTimestampHeader) + "."))
+		mac.Write(body)
+		if r.Header.Get(workers.WebhookSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("invalid webhook signature")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	// And: An offer whose merchant subscribed to budget events only
+	occurredAt := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true})
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-1", "merchant-1", server.URL, "top-secret", []entities.OfferEventType{entities.BudgetExhausted}))
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-2", "merchant-2", server.URL, "other-secret", nil))
+
+	sink := sinks.NewWebhookSink(use_cases.NewEnqueueWebhookDeliveriesUseCase(offerRepo, subscriptionRepo, deliveryRepo))
+	dispatcher := workers.NewWebhookDispatcher(deliveryRepo, subscriptionRepo, server.Client(), 3, time.Second, time.Minute, time.Second, 10)
+
+	// When: The outbox hands over a gained and an exhausted event
+	err := sink.Send(t.Context(), &dtos.OfferEventBatchDto{Events: []dtos.OfferEventDto{
+		{ID: "evt-1", Type: string(entities.EligibilityGained), UserID: "user-1", OfferID: "offer-1", OccurredAt: occurredAt},
+		{ID: "evt-2", Type: string(entities.BudgetExhausted), UserID: "user-1", OfferID: "offer-1", OccurredAt: occurredAt},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	now := time.Now()
+
+	// Then: Only the subscribed event is attempted, and the failure is rescheduled with backoff
+	attempt := func(at time.Time) int {
+		t.Helper()
+		delivered, err := dispatcher.DeliverDue(t.Context(), at)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return delivered
+	}
+	attempt(now)
+	attempt(now.Add(500 * time.Millisecond))
+	if calls != 1 {
+		t.Fatalf("expected 1 call before the 1s backoff elapses, got %d", calls)
+	}
+	attempt(now.Add(time.Second))
+	attempt(now.Add(2 * time.Second))
+	if calls != 2 {
+		t.Fatalf("expected 2 calls before the 2s backoff elapses, got %d", calls)
+	}
+
+	// When: The third attempt fails as well
+	attempt(now.Add(3 * time.Second))
+
+	// Then: The delivery is dead-lettered
+	deadLetters, err := use_cases.NewListDeadLettersUseCase(deliveryRepo).Execute(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(deadLetters.Deliveries) != 1 || deadLetters.Deliveries[0].EventID != "evt-2" || deadLetters.Deliveries[0].Attempts != 3 || deadLetters.Deliveries[0].LastStatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected evt-2 dead-lettered after 3 attempts, got %+v", deadLetters.Deliveries)
+	}
+
+	// When: The endpoint recovers and an operator replays the dead letters
+	status = http.StatusOK
+	replayed, err := use_cases.NewReplayDeadLettersUseCase(deliveryRepo).Execute(t.Context(), &dtos.ReplayDeadLettersRequest{Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The delivery succeeds on the next run
+	if replayed.Replayed != 1 {
+		t.Errorf("expected 1 replayed delivery, got %d", replayed.Replayed)
+	}
+	if delivered := attempt(now.Add(time.Hour)); delivered != 1 {
+		t.Errorf("expected 1 delivered webhook, got %d", delivered)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls in total, got %d", calls)
+	}
+}
+
+func TestWebhookDispatcher_DeliveriesSurviveRestart(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	subscriptionRepo := repositories.NewInMemoryWebhookSubscriptionRepository()
+	deliveryPath := filepath.Join(t.TempDir(), "webhook_deliveries.jsonl")
+
+	// Given: A merchant endpoint that always fails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true})
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-1", "merchant-1", server.URL, "top-secret", nil))
+	batch := &dtos.OfferEventBatchDto{Events: []dtos.OfferEventDto{
+		{ID: "evt-1", Type: string(entities.EligibilityGained), UserID: "user-1", OfferID: "offer-1", OccurredAt: time.Now()},
+		{ID: "evt-2", Type: string(entities.EligibilityLost), UserID: "user-2", OfferID: "offer-1", OccurredAt: time.Now()},
+	}}
+
+	open := func() (*repositories.FileWebhookDeliveryRepository, *sinks.WebhookSink) {
+		t.Helper()
+		deliveryRepo, err := repositories.NewFileWebhookDeliveryRepository(deliveryPath)
+		if err != nil {
+			t.Fatalf("failed to open webhook delivery log: %v", err)
+		}
+		return deliveryRepo, sinks.NewWebhookSink(use_cases.NewEnqueueWebhookDeliveriesUseCase(offerRepo, subscriptionRepo, deliveryRepo))
+	}
+
+	// And: Two enqueued deliveries, one of which is dead-lettered after a single failed attempt
+	deliveryRepo, sink := open()
+	if err := sink.Send(t.Context(), &dtos.OfferEventBatchDto{Events: batch.Events[:1]}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	dispatcher := workers.NewWebhookDispatcher(deliveryRepo, subscriptionRepo, server.Client(), 1, time.Second, time.Minute, time.Second, 10)
+	if _, err := dispatcher.DeliverDue(t.Context(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := sink.Send(t.Context(), batch); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	deliveryRepo.Close()
+
+	// When: The log is reopened and the outbox re-sends the same batch
+	deliveryRepo, sink = open()
+	defer deliveryRepo.Close()
+	if err := sink.Send(t.Context(), batch); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The dead letter and the pending retry are restored without duplicates
+	deadLettered, _ := deliveryRepo.GetByStatus(t.Context(), entities.WebhookDeliveryDeadLettered)
+	if len(deadLettered) != 1 || deadLettered[0].EventID != "evt-1" || deadLettered[0].Attempts != 1 {
+		t.Errorf("expected evt-1 to stay dead-lettered after 1 attempt, got %+v", deadLettered)
+	}
+	pending, _ := deliveryRepo.GetByStatus(t.Context(), entities.WebhookDeliveryPending)
+	if len(pending) != 1 || pending[0].EventID != "evt-2" {
+		t.Errorf("expected evt-2 to stay pending, got %+v", pending)
+	}
+}
+
+func TestWebhookDispatcher_SubscriptionsSurviveRestart(t *testing.T) {
+	offerRepo := repositories.NewInMemoryOfferRepository()
+	dir := t.TempDir()
+	subscriptionPath := filepath.Join(dir, "webhook_subscriptions.jsonl")
+	deliveryPath := filepath.Join(dir, "webhook_deliveries.jsonl")
+
+	// Given: A merchant endpoint that accepts every delivery
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	open := func() (*repositories.FileWebhookSubscriptionRepository, *repositories.FileWebhookDeliveryRepository) {
+		t.Helper()
+		subscriptionRepo, err := repositories.NewFileWebhookSubscriptionRepository(subscriptionPath)
+		if err != nil {
+			t.Fatalf("failed to open webhook subscription log: %v", err)
+		}
+		deliveryRepo, err := repositories.NewFileWebhookDeliveryRepository(deliveryPath)
+		if err != nil {
+			t.Fatalf("failed to open webhook delivery log: %v", err)
+		}
+		return subscriptionRepo, deliveryRepo
+	}
+
+	// And: A subscription, a deleted one, and a delivery enqueued but not yet attempted
+	subscriptionRepo, deliveryRepo := open()
+	offerRepo.Upsert(t.Context(), &entities.Offer{ID: "offer-1", MerchantID: "merchant-1", Active: true})
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-1", "merchant-1", server.URL, "top-secret", nil))
+	subscriptionRepo.Upsert(t.Context(), entities.NewWebhookSubscription("sub-2", "merchant-1", server.URL, "other-secret", nil))
+	if err := subscriptionRepo.Delete(t.Context(), "sub-2"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	sink := sinks.NewWebhookSink(use_cases.NewEnqueueWebhookDeliveriesUseCase(offerRepo, subscriptionRepo, deliveryRepo))
+	err := sink.Send(t.Context(), &dtos.OfferEventBatchDto{Events: []dtos.OfferEventDto{
+		{ID: "evt-1", Type: string(entities.EligibilityGained), UserID: "user-1", OfferID: "offer-1", OccurredAt: time.Now()},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	subscriptionRepo.Close()
+	deliveryRepo.Close()
+
+	// When: Both logs are reopened and the dispatcher runs
+	subscriptionRepo, deliveryRepo = open()
+	defer subscriptionRepo.Close()
+	defer deliveryRepo.Close()
+	dispatcher := workers.NewWebhookDispatcher(deliveryRepo, subscriptionRepo, server.Client(), 3, time.Second, time.Minute, time.Second, 10)
+	delivered, err := dispatcher.DeliverDue(t.Context(), time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The restored delivery finds its subscription and is delivered
+	if delivered != 1 || calls != 1 {
+		t.Errorf("expected 1 delivered webhook, got %d delivered and %d calls", delivered, calls)
+	}
+	// And: The deleted subscription stays deleted
+	subscriptions, _ := subscriptionRepo.GetAll(t.Context())
+	if len(subscriptions) != 1 || subscriptions[0].ID != "sub-1" || subscriptions[0].Secret != "top-secret" {
+		t.Errorf("expected only sub-1 to be restored, got %+v", subscriptions)
+	}
+}
+
+func TestWebhookDispatcher_MissingSubscriptionCountsAsFailedAttempt(t *testing.T) {
+	subscriptionRepo := repositories.NewInMemoryWebhookSubscriptionRepository()
+	deliveryRepo := repositories.NewInMemoryWebhookDeliveryRepository()
+
+	// Given: A delivery whose subscription is not stored
+	now := time.Now()
+	deliveryRepo.Insert(t.Context(), []*entities.WebhookDelivery{{
+		ID: "delivery-1", SubscriptionID: "sub-1", EventID: "evt-1", EventType: entities.EligibilityGained,
+		Payload: []byte(`{}`), Status: entities.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now,
+	}})
+	dispatcher := workers.NewWebhookDispatcher(deliveryRepo, subscriptionRepo, http.DefaultClient, 2, time.Second, time.Minute, time.Second, 10)
+
+	// When: The dispatcher runs once
+	if _, err := dispatcher.DeliverDue(t.Context(), now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The delivery stays pending and is rescheduled
+	delivery, _ := deliveryRepo.GetByID(t.Context(), "delivery-1")
+	if delivery.Status != entities.WebhookDeliveryPending || delivery.Attempts != 1 || !delivery.NextAttemptAt.Equal(now.Add(time.Second)) {
+		t.Fatalf("expected a pending delivery retried in 1s, got %+v", delivery)
+	}
+
+	// When: The subscription is still missing on the last attempt
+	if _, err := dispatcher.DeliverDue(t.Context(), now.Add(time.Second)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Then: The delivery is dead-lettered
+	delivery, _ = deliveryRepo.GetByID(t.Context(), "delivery-1")
+	if delivery.Status != entities.WebhookDeliveryDeadLettered || delivery.Attempts != 2 {
+		t.Errorf("expected the delivery dead-lettered after 2 attempts, got %+v", delivery)
+	}
+}
@@ -0,0 +1,261 @@
+//go:build unix
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Drinnn/eligible-offers-api/internal/app"
+	"github.com/Drinnn/eligible-offers-api/internal/entities"
+	"github.com/Drinnn/eligible-offers-api/internal/repositories"
+	"github.com/Drinnn/eligible-offers-api/internal/server"
+)
+
+type recordingWorker struct {
+	stopped chan struct{}
+}
+
+func (w *recordingWorker) Run(ctx context.Context) {
+	<-ctx.Done()
+	close(w.stopped)
+}
+
+type recordingCloser struct {
+	closed  bool
+	onClose func()
+}
+
+func (c *recordingCloser) Close() error {
+	if c.onClose != nil {
+		c.onClose()
+	}
+	c.closed = true
+	return nil
+}
+
+// blockingTransactionRepository holds the first Insert until released, so shutdown starts
+// while a batch is being stored.
+type blockingTransactionRepository struct {
+	*repositories.InMemoryTransactionRepository
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingTransactionRepository) Insert(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error) {
+	r.once.Do(func() {
+		close(r.started)
+		<-r.release
+	})
+	return r.InMemoryTransactionRepository.Insert(ctx, transactions)
+}
+
+func TestShutdownIntegration_SignalDrainsInFlightIngest(t *testing.T) {
+	// Given: A server whose transaction storage holds the ingest until shutdown has started
+	httpServer := &http.Server{}
+	srv := server.New(httpServer, 300*time.Millisecond, 5*time.Second)
+	transactionRepository := &blockingTransactionRepository{
+		InMemoryTransactionRepository: repositories.NewInMemoryTransactionRepository(),
+		started:                       make(chan struct{}),
+		release:                       make(chan struct{}),
+	}
+	application := newTestAppWithDependencies(t, app.Dependencies{
+		Config:                testConfig(t),
+		State:                 srv,
+		TransactionRepository: transactionRepository,
+	})
+
+	httpServer.Handler = application.Handler
+	shutdownStarted := make(chan struct{})
+	httpServer.RegisterOnShutdown(func() { close(shutdownStarted) })
+
+	worker := &recordingWorker{stopped: make(chan struct{})}
+	var savedAtClose []*entities.Transaction
+	closer := &recordingCloser{onClose: func() {
+		savedAtClose, _ = transactionRepository.GetAll(context.Background())
+	}}
+	srv.AddWorker(worker)
+	srv.AddCloser(closer)
+	for _, c := range application.Closers {
+		srv.AddCloser(c)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	baseURL := "http://" + listener.Addr().String()
+
+	ctx, stop := signal.NotifyContext(t.Context(), syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ctx, listener)
+	}()
+
+	if status := readinessStatus(t, baseURL); status != http.StatusOK {
+		t.Fatalf("Expected /readyz to return 200 while serving, got %d", status)
+	}
+
+	// When: A batch is being ingested
+	ingestBody := []byte(`{
+		"transactions": [
+			{"id": "txn-1", "user_id": "user-1", "merchant_id": "merchant-1", "mcc": "5812", "amount_cents": 1000, "approved_at": "2025-10-20T10:00:00Z"}
+		]
+	}`)
+	ingestStatus := make(chan int, 1)
+	go func() {
+		resp, err := http.Post(baseURL+"/transactions", "application/json", bytes.NewBuffer(ingestBody))
+		if err != nil {
+			ingestStatus <- 0
+			return
+		}
+		resp.Body.Close()
+		ingestStatus <- resp.StatusCode
+	}()
+	<-transactionRepository.started
+
+	// And: The process receives SIGTERM
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("Failed to send SIGTERM: %v", err)
+	}
+
+	// Then: Readiness fails before the server stops accepting connections
+	deadline := time.Now().Add(5 * time.Second)
+	for readinessStatus(t, baseURL) != http.StatusServiceUnavailable {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected /readyz to return 503 while draining")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-shutdownStarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected shutdown to start after SIGTERM")
+	}
+
+	// And: Workers keep running while the request drains
+	select {
+	case <-worker.stopped:
+		t.Fatal("Expected worker to keep running while requests drain")
+	default:
+	}
+
+	// When: Storing the batch finishes
+	close(transactionRepository.release)
+
+	// Then: The ingest completes successfully
+	if status := <-ingestStatus; status != http.StatusCreated {
+		t.Fatalf("Expected in-flight ingest to return 201, got %d", status)
+	}
+
+	// And: The server exits cleanly after stopping workers and closing storage
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			t.Fatalf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected server to exit after draining")
+	}
+	select {
+	case <-worker.stopped:
+	default:
+		t.Error("Expected worker to be stopped")
+	}
+	if !closer.closed {
+		t.Error("Expected storage to be closed")
+	}
+	if len(savedAtClose) != 1 || savedAtClose[0].ID != "txn-1" {
+		t.Errorf("Expected the in-flight batch to be saved before storage closed, got %d transactions", len(savedAtClose))
+	}
+
+	// And: New connections are refused
+	if _, err := http.Get(baseURL + "/mccs"); err == nil {
+		t.Error("Expected new requests to fail after shutdown")
+	}
+}
+
+func TestShutdownIntegration_ClosesStorageAfterCutOffHandlers(t *testing.T) {
+	// Given: A server with a short drain timeout and a handler held past it
+	httpServer := &http.Server{}
+	srv := server.New(httpServer, 0, 100*time.Millisecond)
+
+	handlerStarted := make(chan struct{})
+	releaseHandler := make(chan struct{})
+	closedDuringHandler := make(chan bool, 1)
+	closer := &recordingCloser{}
+	httpServer.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(handlerStarted)
+		<-releaseHandler
+		closedDuringHandler <- closer.closed
+	})
+	srv.AddCloser(closer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ctx, listener)
+	}()
+
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-handlerStarted
+
+	// When: Shutdown starts and the drain timeout passes with the handler still running
+	cancel()
+
+	// Then: The server waits for the handler instead of closing storage under it
+	select {
+	case err := <-serveErr:
+		t.Fatalf("Expected the server to wait for the handler, exited with %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// When: The handler returns
+	close(releaseHandler)
+
+	// Then: Storage was still open while it ran, and is closed once it returned
+	if <-closedDuringHandler {
+		t.Error("Expected storage to stay open while the handler ran")
+	}
+	select {
+	case err := <-serveErr:
+		if err == nil {
+			t.Error("Expected the cut off drain to be reported")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected server to exit after the handler returned")
+	}
+	if !closer.closed {
+		t.Error("Expected storage to be closed")
+	}
+}
+
+func readinessStatus(t *testing.T, baseURL string) int {
+	t.Helper()
+	resp, err := http.Get(baseURL + "/readyz")
+	if err != nil {
+		t.Fatalf("Failed to check readiness: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}